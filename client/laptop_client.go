@@ -14,6 +14,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 //LaptopClient is a client to call laptop service RPCs
@@ -52,17 +53,93 @@ func (laptopClient *LaptopClient) CreateLaptop(laptop *pb.Laptop) {
 	log.Printf("Created laptop with id: %s", res.Id)
 }
 
-//SearchLaptop calls search laptop RPC
-func (laptopClient *LaptopClient) SearchLaptop(filter *pb.Filter) {
-	log.Print("Seach filter: ", filter)
+//GetLaptop calls get laptop RPC, it returns the laptop with its current version
+func (laptopClient *LaptopClient) GetLaptop(laptopID string) (*pb.Laptop, uint64, error) {
+	req := &pb.GetLaptopRequest{
+		Id: laptopID,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := laptopClient.service.GetLaptop(ctx, req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("Cannot get laptop: %v", err)
+	}
+
+	log.Printf("Got laptop with id: %s, version: %d", res.GetLaptop().GetId(), res.GetVersion())
+	return res.GetLaptop(), res.GetVersion(), nil
+}
+
+//UpdateLaptop calls update laptop RPC, only the given field paths are updated if any are provided.
+//A zero expectedVersion updates the laptop whatever its current version is
+func (laptopClient *LaptopClient) UpdateLaptop(laptop *pb.Laptop, expectedVersion uint64, paths ...string) (uint64, error) {
+	req := &pb.UpdateLaptopRequest{
+		Laptop:          laptop,
+		ExpectedVersion: expectedVersion,
+	}
+
+	if len(paths) > 0 {
+		req.UpdateMask = &fieldmaskpb.FieldMask{Paths: paths}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := laptopClient.service.UpdateLaptop(ctx, req)
+	if err != nil {
+		return 0, fmt.Errorf("Cannot update laptop: %v", err)
+	}
+
+	log.Printf("Updated laptop with id: %s to version: %d", laptop.GetId(), res.GetVersion())
+	return res.GetVersion(), nil
+}
+
+//DeleteLaptop calls delete laptop RPC. A zero expectedVersion deletes the laptop whatever its current version is
+func (laptopClient *LaptopClient) DeleteLaptop(laptopID string, expectedVersion uint64) error {
+	req := &pb.DeleteLaptopRequest{
+		Id:              laptopID,
+		ExpectedVersion: expectedVersion,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := laptopClient.service.DeleteLaptop(ctx, req)
+	if err != nil {
+		return fmt.Errorf("Cannot delete laptop: %v", err)
+	}
+
+	log.Printf("Deleted laptop with id: %s", laptopID)
+	return nil
+}
+
+//ListLaptops calls list laptops RPC, it returns one page of laptops and the token of the next page
+func (laptopClient *LaptopClient) ListLaptops(pageSize uint32, pageToken string) ([]*pb.Laptop, string, error) {
+	req := &pb.ListLaptopsRequest{
+		PageSize:  pageSize,
+		PageToken: pageToken,
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	req := &pb.SearchLaptopRequest{
-		Filter: filter,
+	res, err := laptopClient.service.ListLaptops(ctx, req)
+	if err != nil {
+		return nil, "", fmt.Errorf("Cannot list laptops: %v", err)
 	}
 
+	log.Printf("Listed %d laptops", len(res.GetLaptops()))
+	return res.GetLaptops(), res.GetNextPageToken(), nil
+}
+
+//SearchLaptop calls search laptop RPC
+func (laptopClient *LaptopClient) SearchLaptop(req *pb.SearchLaptopRequest) {
+	log.Print("Seach filter: ", req.GetFilter())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
 	stream, err := laptopClient.service.SearchLaptop(ctx, req)
 	if err != nil {
 		log.Fatal("Cannot search laptop: ", err)
@@ -82,6 +159,50 @@ func (laptopClient *LaptopClient) SearchLaptop(filter *pb.Filter) {
 	}
 }
 
+//SearchFacets calls search facets RPC
+func (laptopClient *LaptopClient) SearchFacets(req *pb.SearchFacetsRequest) (*pb.SearchFacetsResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := laptopClient.service.SearchFacets(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot search facets: %v", err)
+	}
+
+	log.Printf("Found facets over %d laptops", res.GetTotal())
+	return res, nil
+}
+
+//WatchLaptops calls watch laptops RPC and passes every event to found until ctx is done.
+//It returns nil when ctx is done, or the first error of the stream or of found
+func (laptopClient *LaptopClient) WatchLaptops(
+	ctx context.Context,
+	req *pb.WatchLaptopsRequest,
+	found func(event *pb.LaptopEvent) error,
+) error {
+	log.Print("Watch filter: ", req.GetFilter())
+
+	stream, err := laptopClient.service.WatchLaptops(ctx, req)
+	if err != nil {
+		return fmt.Errorf("Cannot watch laptops: %v", err)
+	}
+
+	for {
+		res, err := stream.Recv()
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("Cannot receive response: %v", err)
+		}
+
+		err = found(res.GetEvent())
+		if err != nil {
+			return err
+		}
+	}
+}
+
 //UploadImage calls upload image RPC
 func (laptopClient *LaptopClient) UploadImage(laptopID, imagePath string) {
 
@@ -145,6 +266,181 @@ func (laptopClient *LaptopClient) UploadImage(laptopID, imagePath string) {
 	log.Printf("Image uploaded with id: %s, size: %d", res.GetId(), res.GetSize())
 }
 
+//UploadImageResumable uploads an image in a resumable session. It starts a new upload if uploadID is empty,
+//otherwise it asks the server how much of that upload is committed and only sends the rest.
+//The upload ID is returned even when the upload fails, so that it can be resumed by calling again with it
+func (laptopClient *LaptopClient) UploadImageResumable(
+	ctx context.Context,
+	laptopID string,
+	imagePath string,
+	uploadID string,
+) (string, *pb.UploadImageResponse, error) {
+	file, err := os.Open(imagePath)
+	if err != nil {
+		return uploadID, nil, fmt.Errorf("Cannot open image file: %v", err)
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return uploadID, nil, fmt.Errorf("Cannot read image file: %v", err)
+	}
+
+	var offset int64
+	if uploadID == "" {
+		req := &pb.StartUploadRequest{
+			Info: &pb.ImageInfo{
+				LaptopId:  laptopID,
+				ImageType: filepath.Ext(imagePath),
+			},
+		}
+
+		res, err := laptopClient.service.StartUpload(ctx, req)
+		if err != nil {
+			return "", nil, fmt.Errorf("Cannot start upload: %v", err)
+		}
+		uploadID = res.GetUploadId()
+	} else {
+		res, err := laptopClient.service.QueryUpload(ctx, &pb.QueryUploadRequest{UploadId: uploadID})
+		if err != nil {
+			return uploadID, nil, fmt.Errorf("Cannot query upload: %v", err)
+		}
+		offset = int64(res.GetCommittedSize())
+		log.Printf("Resuming upload %s from offset %d", uploadID, offset)
+	}
+
+	if offset < stat.Size() {
+		_, err = file.Seek(offset, io.SeekStart)
+		if err != nil {
+			return uploadID, nil, fmt.Errorf("Cannot seek image file: %v", err)
+		}
+
+		err = laptopClient.uploadChunks(ctx, uploadID, offset, file)
+		if err != nil {
+			return uploadID, nil, err
+		}
+	}
+
+	res, err := laptopClient.service.FinishUpload(ctx, &pb.FinishUploadRequest{UploadId: uploadID, Size: uint64(stat.Size())})
+	if err != nil {
+		return uploadID, nil, fmt.Errorf("Cannot finish upload: %v", err)
+	}
+
+	log.Printf("Image uploaded with id: %s, size: %d", res.GetId(), res.GetSize())
+	return uploadID, res, nil
+}
+
+//uploadChunks sends the data of reader to an upload, starting at offset
+func (laptopClient *LaptopClient) uploadChunks(ctx context.Context, uploadID string, offset int64, reader io.Reader) error {
+	stream, err := laptopClient.service.UploadChunks(ctx)
+	if err != nil {
+		return fmt.Errorf("Cannot upload chunks: %v", err)
+	}
+
+	req := &pb.UploadChunksRequest{
+		Data: &pb.UploadChunksRequest_Position_{
+			Position: &pb.UploadChunksRequest_Position{
+				UploadId: uploadID,
+				Offset:   uint64(offset),
+			},
+		},
+	}
+
+	err = stream.Send(req)
+	if err != nil {
+		return fmt.Errorf("Cannot send stream request: %v - %v", err, stream.RecvMsg(nil))
+	}
+
+	buffer := make([]byte, 1024)
+	for {
+		n, err := reader.Read(buffer)
+		if n > 0 {
+			req := &pb.UploadChunksRequest{
+				Data: &pb.UploadChunksRequest_ChunkData{
+					ChunkData: buffer[:n],
+				},
+			}
+
+			err := stream.Send(req)
+			if err != nil {
+				return fmt.Errorf("Cannot send chunk to server: %v - %v", err, stream.RecvMsg(nil))
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("Cannot read chunk to buffer: %v", err)
+		}
+	}
+
+	_, err = stream.CloseAndRecv()
+	if err != nil {
+		return fmt.Errorf("Couldn't receive response: %v", err)
+	}
+	return nil
+}
+
+//DownloadImage calls download image RPC, it writes the image data to w and returns the info of the image.
+//A variant other than 0 asks for the image resized to that longest side, if it is generated
+func (laptopClient *LaptopClient) DownloadImage(imageID string, variant uint32, w io.Writer) (*pb.ImageMetadata, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	req := &pb.DownloadImageRequest{
+		ImageId: imageID,
+		Variant: variant,
+	}
+
+	stream, err := laptopClient.service.DownloadImage(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot download image: %v", err)
+	}
+
+	var info *pb.ImageMetadata
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Cannot receive response: %v", err)
+		}
+
+		if res.GetInfo() != nil {
+			info = res.GetInfo()
+			continue
+		}
+
+		_, err = w.Write(res.GetChunkData())
+		if err != nil {
+			return nil, fmt.Errorf("Cannot write chunk data: %v", err)
+		}
+	}
+
+	log.Printf("Image downloaded with id: %s, variant: %d, size: %d", info.GetId(), info.GetVariant(), info.GetSize())
+	return info, nil
+}
+
+//ListImages calls list images RPC, a variant other than 0 lists that variant of each image if it is generated
+func (laptopClient *LaptopClient) ListImages(laptopID string, variant uint32) ([]*pb.ImageMetadata, error) {
+	req := &pb.ListImagesRequest{
+		LaptopId: laptopID,
+		Variant:  variant,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := laptopClient.service.ListImages(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot list images: %v", err)
+	}
+
+	log.Printf("Listed %d images of laptop %s", len(res.GetImages()), laptopID)
+	return res.GetImages(), nil
+}
+
 //RateLaptop calls rate laptop RPC
 func (laptopClient *LaptopClient) RateLaptop(laptopIDs []string, scores []float64) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -195,3 +491,140 @@ func (laptopClient *LaptopClient) RateLaptop(laptopIDs []string, scores []float6
 	err = <-waitResponse
 	return err
 }
+
+//ExportCatalog calls export catalog RPC and passes every catalog entry to found
+func (laptopClient *LaptopClient) ExportCatalog(ctx context.Context, found func(entry *pb.CatalogEntry) error) error {
+	stream, err := laptopClient.service.ExportCatalog(ctx, &pb.ExportCatalogRequest{})
+	if err != nil {
+		return fmt.Errorf("Cannot export catalog: %v", err)
+	}
+
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("Cannot receive response: %v", err)
+		}
+
+		err = found(res.GetEntry())
+		if err != nil {
+			return err
+		}
+	}
+}
+
+//ImportCatalog calls import catalog RPC with the entries returned by next, until next returns io.EOF.
+//It returns the summary of the import
+func (laptopClient *LaptopClient) ImportCatalog(
+	ctx context.Context,
+	mode pb.ImportCatalogRequest_Mode,
+	next func() (*pb.CatalogEntry, error),
+) (*pb.ImportCatalogResponse, error) {
+	stream, err := laptopClient.service.ImportCatalog(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot import catalog: %v", err)
+	}
+
+	req := &pb.ImportCatalogRequest{
+		Data: &pb.ImportCatalogRequest_ImportMode{
+			ImportMode: mode,
+		},
+	}
+
+	for {
+		err = stream.Send(req)
+		if err != nil {
+			return nil, fmt.Errorf("Cannot send stream request: %v - %v", err, stream.RecvMsg(nil))
+		}
+
+		entry, err := next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		req = &pb.ImportCatalogRequest{
+			Data: &pb.ImportCatalogRequest_Entry{
+				Entry: entry,
+			},
+		}
+	}
+
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't receive response: %v", err)
+	}
+
+	log.Printf("Imported catalog: %d created, %d updated, %d skipped, %d failed",
+		res.GetCreated(), res.GetUpdated(), res.GetSkipped(), res.GetFailed())
+	return res, nil
+}
+
+//CreateLaptops calls create laptops RPC with the given laptops and passes the result of each one to created,
+//in the order of the laptops
+func (laptopClient *LaptopClient) CreateLaptops(
+	ctx context.Context,
+	laptops []*pb.Laptop,
+	allOrNothing bool,
+	created func(res *pb.CreateLaptopsResponse) error,
+) error {
+	stream, err := laptopClient.service.CreateLaptops(ctx)
+	if err != nil {
+		return fmt.Errorf("Cannot create laptops: %v", err)
+	}
+
+	waitResponse := make(chan error, 1)
+	go func() {
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				waitResponse <- nil
+				return
+			}
+			if err != nil {
+				waitResponse <- fmt.Errorf("Cannot receive response: %v", err)
+				return
+			}
+
+			err = created(res)
+			if err != nil {
+				waitResponse <- err
+				return
+			}
+		}
+	}()
+
+	req := &pb.CreateLaptopsRequest{
+		Data: &pb.CreateLaptopsRequest_Options_{
+			Options: &pb.CreateLaptopsRequest_Options{AllOrNothing: allOrNothing},
+		},
+	}
+	err = stream.Send(req)
+	if err != nil {
+		return fmt.Errorf("Cannot send stream request: %v - %v", err, stream.RecvMsg(nil))
+	}
+
+	for _, laptop := range laptops {
+		req = &pb.CreateLaptopsRequest{
+			Data: &pb.CreateLaptopsRequest_Laptop{
+				Laptop: laptop,
+			},
+		}
+
+		err = stream.Send(req)
+		if err != nil {
+			return fmt.Errorf("Cannot send stream request: %v - %v", err, stream.RecvMsg(nil))
+		}
+	}
+
+	err = stream.CloseSend()
+	if err != nil {
+		return fmt.Errorf("Cannot close send: %v", err)
+	}
+
+	return <-waitResponse
+}
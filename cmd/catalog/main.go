@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"demo-grpc/client"
+	"demo-grpc/pb"
+	"demo-grpc/serializer"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+const refreshDuration = 30 * time.Second
+
+const usage = `Usage: catalog [flags] export|import <file>
+
+Exports the catalog of the server to a file, or imports a file exported before.
+The file holds one catalog entry per laptop, length-delimited protobuf or NDJSON depending on -format.
+
+Flags:
+`
+
+func authMethods() map[string]bool {
+	const laptopServicePath = "/proto.LaptopService/"
+	return map[string]bool{
+		laptopServicePath + "ExportCatalog": true,
+		laptopServicePath + "ImportCatalog": true,
+	}
+}
+
+//catalogFormat writes and reads the entries of a catalog file
+type catalogFormat struct {
+	write func(writer io.Writer, message proto.Message) error
+	read  func(reader *bufio.Reader, message proto.Message) error
+}
+
+var catalogFormats = map[string]catalogFormat{
+	"delimited": {serializer.WriteDelimited, serializer.ReadDelimited},
+	"ndjson":    {serializer.WriteJSONLine, serializer.ReadJSONLine},
+}
+
+var importModes = map[string]pb.ImportCatalogRequest_Mode{
+	"skip":   pb.ImportCatalogRequest_SKIP_EXISTING,
+	"upsert": pb.ImportCatalogRequest_UPSERT,
+}
+
+func exportCatalog(laptopClient *client.LaptopClient, format catalogFormat, fileName string) error {
+	file, err := os.Create(fileName)
+	if err != nil {
+		return fmt.Errorf("Cannot create catalog file: %v", err)
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	exported := 0
+
+	err = laptopClient.ExportCatalog(context.Background(), func(entry *pb.CatalogEntry) error {
+		exported++
+		return format.write(writer, entry)
+	})
+	if err != nil {
+		return err
+	}
+
+	err = writer.Flush()
+	if err != nil {
+		return fmt.Errorf("Cannot write catalog file: %v", err)
+	}
+
+	log.Printf("Exported %d laptops to %s", exported, fileName)
+	return file.Close()
+}
+
+func importCatalog(laptopClient *client.LaptopClient, format catalogFormat, mode pb.ImportCatalogRequest_Mode, fileName string) error {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return fmt.Errorf("Cannot open catalog file: %v", err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	res, err := laptopClient.ImportCatalog(context.Background(), mode, func() (*pb.CatalogEntry, error) {
+		entry := &pb.CatalogEntry{}
+		err := format.read(reader, entry)
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("Cannot read catalog file: %v", err)
+		}
+		return entry, err
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, failure := range res.GetFailures() {
+		log.Printf("Cannot import laptop %s: %s", failure.GetLaptopId(), failure.GetMessage())
+	}
+	return nil
+}
+
+func main() {
+	serverAddress := flag.String("address", "", "the server address")
+	username := flag.String("username", "admin1", "the user to log in as, it must be an admin")
+	password := flag.String("password", "secret", "the password of the user")
+	formatName := flag.String("format", "delimited", "the format of the catalog file: delimited or ndjson")
+	modeName := flag.String("mode", "skip", "what an import does with laptops that already exist: skip or upsert")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	format, ok := catalogFormats[*formatName]
+	if !ok {
+		log.Fatalf("Unknown catalog format: %s", *formatName)
+	}
+
+	mode, ok := importModes[*modeName]
+	if !ok {
+		log.Fatalf("Unknown import mode: %s", *modeName)
+	}
+
+	log.Printf("Dial server: %s", *serverAddress)
+
+	cc1, err := grpc.Dial(*serverAddress, grpc.WithInsecure())
+	if err != nil {
+		log.Fatal("Cannot Dial server: ", err)
+	}
+
+	authClient := client.NewAuthClient(cc1, *username, *password)
+	interceptor, err := client.NewAuthInterceptor(authClient, authMethods(), refreshDuration)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cc2, err := grpc.Dial(
+		*serverAddress,
+		grpc.WithInsecure(),
+		grpc.WithUnaryInterceptor(interceptor.Unary()),
+		grpc.WithStreamInterceptor(interceptor.Stream()),
+	)
+	if err != nil {
+		log.Fatalf("Cannot dial server: %v", err)
+	}
+
+	laptopClient := client.NewLaptopClient(cc2)
+	fileName := flag.Arg(1)
+
+	switch flag.Arg(0) {
+	case "export":
+		err = exportCatalog(laptopClient, format, fileName)
+	case "import":
+		err = importCatalog(laptopClient, format, mode, fileName)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -29,13 +29,19 @@ func testSearchLaptop(laptopClient *client.LaptopClient) {
 		MinRam:      &pb.Memory{Value: 8, Unit: pb.Memory_GIGABYTE},
 	}
 
-	laptopClient.SearchLaptop(filter)
+	req := &pb.SearchLaptopRequest{
+		Filter:     filter,
+		SortBy:     pb.SearchLaptopRequest_PRICE,
+		MaxResults: 5,
+	}
+
+	laptopClient.SearchLaptop(req)
 }
 
 func testUploadImage(laptopClient *client.LaptopClient) {
 	laptop := sample.NewLaptop()
 	laptopClient.CreateLaptop(laptop)
-	laptopClient.UploadImage(laptop.GetId(), "tmp/laptop.jpg")
+	laptopClient.UploadImage(laptop.GetId(), "tmp/laptop.webp")
 }
 
 func testRateLaptop(laptopClient *client.LaptopClient) {
@@ -79,6 +85,11 @@ func authMethods() map[string]bool {
 	const laptopServicePath = "/proto.LaptopService/"
 	return map[string]bool{
 		laptopServicePath + "CreateLaptop": true,
+		laptopServicePath + "GetLaptop":    true,
+		laptopServicePath + "UpdateLaptop": true,
+		laptopServicePath + "DeleteLaptop": true,
+		laptopServicePath + "ListLaptops":  true,
+		laptopServicePath + "WatchLaptops": true,
 		laptopServicePath + "UploadImage":  true,
 		laptopServicePath + "RateLaptop":   true,
 	}
@@ -1,12 +1,20 @@
 package main
 
 import (
+	"context"
 	"demo-grpc/pb"
 	"demo-grpc/service"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
 	"time"
 
 	"google.golang.org/grpc"
@@ -26,41 +34,234 @@ func createUser(userStore service.UserStore, username, password, role string) er
 	if err != nil {
 		return err
 	}
-	return userStore.Save(user)
+
+	//users kept from a previous run are left as they are
+	err = userStore.Save(user)
+	if errors.Is(err, service.ErrAlreadyExists) {
+		return nil
+	}
+	return err
 }
 
 const (
 	secretKey     = "secret"
 	tokenDuration = 15 * time.Minute
+	//variantQueueSize is the number of uploaded images that can wait for their variants to be generated
+	variantQueueSize = 100
+	//variantBackfillInterval is how often the images that miss some of their variants are queued again
+	variantBackfillInterval = time.Hour
 )
 
+//sweepInterval returns how often expired data is removed, often enough that nothing outstays the retention by much
+func sweepInterval(retention time.Duration) time.Duration {
+	interval := retention / 10
+	if interval > time.Hour {
+		interval = time.Hour
+	}
+	if interval < time.Second {
+		interval = time.Second
+	}
+	return interval
+}
+
 func accessibleRoles() map[string][]string {
 	const laptopServicePath = "/proto.LaptopService/"
+	const adminServicePath = "/proto.AdminService/"
 	return map[string][]string{
-		laptopServicePath + "CreateLaptop": {"admin"},
-		laptopServicePath + "UploadImage":  {"admin"},
-		laptopServicePath + "RateLaptop":   {"admin", "user"},
+		laptopServicePath + "CreateLaptop":          {"admin"},
+		laptopServicePath + "CreateLaptops":         {"admin"},
+		laptopServicePath + "GetLaptop":             {"admin", "user"},
+		laptopServicePath + "UpdateLaptop":          {"admin"},
+		laptopServicePath + "DeleteLaptop":          {"admin"},
+		laptopServicePath + "ListLaptops":           {"admin", "user"},
+		laptopServicePath + "WatchLaptops":          {"admin", "user"},
+		laptopServicePath + "UploadImage":           {"admin"},
+		laptopServicePath + "StartUpload":           {"admin"},
+		laptopServicePath + "UploadChunks":          {"admin"},
+		laptopServicePath + "QueryUpload":           {"admin"},
+		laptopServicePath + "FinishUpload":          {"admin"},
+		laptopServicePath + "DownloadImage":         {"admin", "user"},
+		laptopServicePath + "ListImages":            {"admin", "user"},
+		laptopServicePath + "RateLaptop":            {"admin", "user"},
+		laptopServicePath + "ExportCatalog":         {"admin"},
+		laptopServicePath + "ImportCatalog":         {"admin"},
+		laptopServicePath + "ListLaptopRevisions":   {"admin"},
+		laptopServicePath + "GetLaptopRevision":     {"admin"},
+		laptopServicePath + "RestoreLaptopRevision": {"admin"},
+		laptopServicePath + "ListTrashedLaptops":    {"admin"},
+		laptopServicePath + "RestoreTrashedLaptop":  {"admin"},
+		adminServicePath + "Snapshot":               {"admin"},
+		adminServicePath + "Restore":                {"admin"},
+	}
+}
+
+//stores are the stores the server keeps its data in
+type stores struct {
+	laptop   service.LaptopStore
+	user     service.UserStore
+	image    service.ImageStore
+	rating   service.RatingStore
+	revision service.RevisionStore
+	trash    service.TrashStore
+	//closers are closed in order when the server stops
+	closers []io.Closer
+}
+
+//close closes what the stores keep open, like their database files
+func (s *stores) close() error {
+	for _, closer := range s.closers {
+		err := closer.Close()
+		if err != nil {
+			return err
+		}
 	}
+	return nil
+}
+
+//openStores opens the stores described by spec, which is either "memory", "disk:" followed by the folder
+//where the laptops, their history and the trash are kept, "sqlite:" followed by the SQLite database file where
+//the laptops, their history and the trash are kept, or "bolt:" followed by the database file where every store is kept
+func openStores(spec string) (*stores, error) {
+	s := &stores{
+		user:     service.NewInMemoryUserStore(),
+		image:    service.NewDiskImageStore("img"),
+		rating:   service.NewInMemoryRatingStore(),
+		revision: service.NewInMemoryRevisionStore(),
+		trash:    service.NewInMemoryTrashStore(),
+	}
+
+	switch {
+	case spec == "memory":
+		s.laptop = service.NewInMemoryLaptopStore()
+	case strings.HasPrefix(spec, "disk:"):
+		folder := strings.TrimPrefix(spec, "disk:")
+		laptopStore, err := service.NewDiskLaptopStore(folder, 0)
+		if err != nil {
+			return nil, err
+		}
+		s.laptop = laptopStore
+		s.closers = append(s.closers, laptopStore)
+
+		revisionStore, err := service.NewDiskRevisionStore(folder)
+		if err != nil {
+			s.close()
+			return nil, err
+		}
+		s.revision = revisionStore
+		s.closers = append(s.closers, revisionStore)
+
+		s.trash, err = service.NewDiskTrashStore(filepath.Join(folder, "trash"))
+		if err != nil {
+			s.close()
+			return nil, err
+		}
+	case strings.HasPrefix(spec, "sqlite:"):
+		db, err := service.OpenSQLiteDB(strings.TrimPrefix(spec, "sqlite:"))
+		if err != nil {
+			return nil, err
+		}
+		s.closers = append(s.closers, db)
+
+		s.laptop = service.NewSQLLaptopStore(db)
+		s.revision = service.NewSQLRevisionStore(db)
+		s.trash = service.NewSQLTrashStore(db)
+	case strings.HasPrefix(spec, "bolt:"):
+		db, err := service.OpenBoltDB(strings.TrimPrefix(spec, "bolt:"))
+		if err != nil {
+			return nil, err
+		}
+		s.laptop = service.NewBoltLaptopStore(db)
+		s.user = service.NewBoltUserStore(db)
+		s.image = service.NewBoltImageStore(db, "img")
+		s.rating = service.NewBoltRatingStore(db)
+		s.revision = service.NewBoltRevisionStore(db)
+		s.trash = service.NewBoltTrashStore(db)
+		s.closers = append(s.closers, db)
+	default:
+		return nil, fmt.Errorf("Unknown store: %s", spec)
+	}
+
+	return s, nil
 }
 
 func main() {
 	port := flag.Int("port", 0, "the server port")
+	store := flag.String("store", "memory", "where data is stored: memory, disk:<folder> to keep laptops, their history and the trash on disk, sqlite:<file> to keep them in a SQLite database, or bolt:<file> to keep everything in a bolt database")
+	snapshotFolder := flag.String("snapshot-dir", "snapshot", "the folder where snapshots are kept")
+	restore := flag.String("restore", "", "the name of a snapshot to restore before serving")
+	trashRetention := flag.Duration("trash-retention", 30*24*time.Hour, "how long deleted laptops stay in the trash before they are purged, 0 keeps them forever")
+	uploadFolder := flag.String("upload-dir", "upload", "the folder where the resumable image uploads in progress are kept")
+	uploadExpiry := flag.Duration("upload-expiry", 24*time.Hour, "how long a resumable upload that gets no data is kept before it is removed, 0 keeps uploads forever")
+	imageSizeLimits := flag.String("image-size-limits", "", "the largest sizes of uploaded images by type, like *=1MiB,.jpg=8MiB where * sets the default of 1MiB")
+	variantWorkers := flag.Int("variant-workers", 2, "the number of workers generating the resized variants of uploaded images, 0 generates none")
 	flag.Parse()
 	log.Printf("Started server on port %d", *port)
 
-	userStore := service.NewInMemoryUserStore()
-	err := seedUsers(userStore)
+	stores, err := openStores(*store)
+	if err != nil {
+		log.Fatalf("Cannot open stores: %v", err)
+	}
+
+	gate := service.NewWriteGate()
+	adminServer := service.NewAdminServer(gate, *snapshotFolder, stores.laptop, stores.user, stores.rating, stores.image)
+
+	if *restore != "" {
+		_, err = adminServer.Restore(context.Background(), &pb.RestoreRequest{Name: *restore})
+		if err != nil {
+			log.Fatalf("Cannot restore snapshot: %v", err)
+		}
+	}
+
+	//seeded after the restore, so that the snapshot cannot lock the admins out
+	err = seedUsers(stores.user)
 	if err != nil {
 		log.Fatalf("Cannot seed users: %v", err)
 	}
 
 	jwtManager := service.NewJWTManager(secretKey, tokenDuration)
-	authServer := service.NewAuthServer(userStore, *jwtManager)
+	authServer := service.NewAuthServer(gate.UserStore(stores.user), *jwtManager)
 
-	laptopStore := service.NewInMemoryLaptopStore()
-	imageStore := service.NewDiskImageStore("img")
-	ratingStore := service.NewInMemoryRatingStore()
-	laptopServer := service.NewLaptopServer(laptopStore, imageStore, ratingStore)
+	uploadStore, err := service.NewDiskUploadStore(*uploadFolder)
+	if err != nil {
+		log.Fatalf("Cannot open upload store: %v", err)
+	}
+	if *uploadExpiry > 0 {
+		go service.RunUploadExpiry(context.Background(), uploadStore, *uploadExpiry, sweepInterval(*uploadExpiry))
+	}
+
+	laptopServer := service.NewLaptopServer(
+		gate.LaptopStore(stores.laptop),
+		gate.ImageStore(stores.image),
+		gate.RatingStore(stores.rating),
+		stores.revision,
+		gate.TrashStore(stores.trash),
+		uploadStore,
+	)
+
+	limits, err := service.ParseImageSizeLimits(*imageSizeLimits)
+	if err != nil {
+		log.Fatalf("Cannot parse image size limits: %v", err)
+	}
+	laptopServer.SetImageSizeLimits(limits)
+
+	if *variantWorkers > 0 {
+		generator := service.NewVariantGenerator(gate.ImageStore(stores.image), variantQueueSize)
+		laptopServer.SetVariantGenerator(generator)
+		go generator.Run(context.Background(), *variantWorkers)
+		go generator.RunBackfill(context.Background(), stores.laptop, variantBackfillInterval)
+	}
+
+	if *trashRetention > 0 {
+		purger := service.NewTrashPurger(
+			*trashRetention,
+			stores.laptop,
+			gate.TrashStore(stores.trash),
+			gate.ImageStore(stores.image),
+			gate.RatingStore(stores.rating),
+			stores.revision,
+		)
+		go purger.Run(context.Background(), sweepInterval(*trashRetention))
+	}
 
 	interceptor := service.NewAuthInterceptor(jwtManager, accessibleRoles())
 	grpcServer := grpc.NewServer(
@@ -70,6 +271,7 @@ func main() {
 
 	pb.RegisterAuthServiceServer(grpcServer, authServer)
 	pb.RegisterLaptopServiceServer(grpcServer, laptopServer)
+	pb.RegisterAdminServiceServer(grpcServer, adminServer)
 	reflection.Register(grpcServer)
 
 	address := fmt.Sprintf("0.0.0.0:%d", *port)
@@ -78,8 +280,22 @@ func main() {
 		log.Fatal("Cannot start the server: ", err)
 	}
 
+	//an interrupted server finishes its calls and closes the stores, so that no database is left open
+	go func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		<-signals
+		log.Print("Stopping the server")
+		grpcServer.GracefulStop()
+	}()
+
 	err = grpcServer.Serve(lis)
 	if err != nil {
 		log.Fatal("Cannot start the server: ", err)
 	}
+
+	err = stores.close()
+	if err != nil {
+		log.Fatal("Cannot close the stores: ", err)
+	}
 }
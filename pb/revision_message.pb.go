@@ -0,0 +1,291 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.23.0
+// 	protoc        v3.12.3
+// source: revision_message.proto
+
+package pb
+
+import (
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+type LaptopRevision_Operation int32
+
+const (
+	LaptopRevision_UNKNOWN LaptopRevision_Operation = 0
+	LaptopRevision_CREATE  LaptopRevision_Operation = 1
+	LaptopRevision_UPDATE  LaptopRevision_Operation = 2
+	LaptopRevision_DELETE  LaptopRevision_Operation = 3
+	LaptopRevision_RESTORE LaptopRevision_Operation = 4
+	LaptopRevision_IMPORT  LaptopRevision_Operation = 5
+	LaptopRevision_PURGE   LaptopRevision_Operation = 6
+)
+
+// Enum value maps for LaptopRevision_Operation.
+var (
+	LaptopRevision_Operation_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "CREATE",
+		2: "UPDATE",
+		3: "DELETE",
+		4: "RESTORE",
+		5: "IMPORT",
+		6: "PURGE",
+	}
+	LaptopRevision_Operation_value = map[string]int32{
+		"UNKNOWN": 0,
+		"CREATE":  1,
+		"UPDATE":  2,
+		"DELETE":  3,
+		"RESTORE": 4,
+		"IMPORT":  5,
+		"PURGE":   6,
+	}
+)
+
+func (x LaptopRevision_Operation) Enum() *LaptopRevision_Operation {
+	p := new(LaptopRevision_Operation)
+	*p = x
+	return p
+}
+
+func (x LaptopRevision_Operation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LaptopRevision_Operation) Descriptor() protoreflect.EnumDescriptor {
+	return file_revision_message_proto_enumTypes[0].Descriptor()
+}
+
+func (LaptopRevision_Operation) Type() protoreflect.EnumType {
+	return &file_revision_message_proto_enumTypes[0]
+}
+
+func (x LaptopRevision_Operation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LaptopRevision_Operation.Descriptor instead.
+func (LaptopRevision_Operation) EnumDescriptor() ([]byte, []int) {
+	return file_revision_message_proto_rawDescGZIP(), []int{0, 0}
+}
+
+type LaptopRevision struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LaptopId  string                   `protobuf:"bytes,1,opt,name=laptop_id,json=laptopId,proto3" json:"laptop_id,omitempty"`
+	Revision  uint64                   `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	Operation LaptopRevision_Operation `protobuf:"varint,3,opt,name=operation,proto3,enum=proto.LaptopRevision_Operation" json:"operation,omitempty"`
+	Username  string                   `protobuf:"bytes,4,opt,name=username,proto3" json:"username,omitempty"`
+	Timestamp *timestamp.Timestamp     `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Laptop    *Laptop                  `protobuf:"bytes,6,opt,name=laptop,proto3" json:"laptop,omitempty"`
+	Version   uint64                   `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *LaptopRevision) Reset() {
+	*x = LaptopRevision{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_revision_message_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LaptopRevision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LaptopRevision) ProtoMessage() {}
+
+func (x *LaptopRevision) ProtoReflect() protoreflect.Message {
+	mi := &file_revision_message_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LaptopRevision.ProtoReflect.Descriptor instead.
+func (*LaptopRevision) Descriptor() ([]byte, []int) {
+	return file_revision_message_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LaptopRevision) GetLaptopId() string {
+	if x != nil {
+		return x.LaptopId
+	}
+	return ""
+}
+
+func (x *LaptopRevision) GetRevision() uint64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *LaptopRevision) GetOperation() LaptopRevision_Operation {
+	if x != nil {
+		return x.Operation
+	}
+	return LaptopRevision_UNKNOWN
+}
+
+func (x *LaptopRevision) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *LaptopRevision) GetTimestamp() *timestamp.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *LaptopRevision) GetLaptop() *Laptop {
+	if x != nil {
+		return x.Laptop
+	}
+	return nil
+}
+
+func (x *LaptopRevision) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+var File_revision_message_proto protoreflect.FileDescriptor
+
+var file_revision_message_proto_rawDesc = []byte{
+	0x0a, 0x16, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x14, 0x6c, 0x61, 0x70, 0x74, 0x6f, 0x70, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x81, 0x03, 0x0a, 0x0e, 0x4c, 0x61, 0x70, 0x74, 0x6f,
+	0x70, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x61, 0x70,
+	0x74, 0x6f, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61,
+	0x70, 0x74, 0x6f, 0x70, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x3d, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x61,
+	0x70, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x4f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x38, 0x0a,
+	0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x25, 0x0a, 0x06, 0x6c, 0x61, 0x70, 0x74, 0x6f,
+	0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x4c, 0x61, 0x70, 0x74, 0x6f, 0x70, 0x52, 0x06, 0x6c, 0x61, 0x70, 0x74, 0x6f, 0x70, 0x12, 0x18,
+	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x60, 0x0a, 0x09, 0x4f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e,
+	0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x10, 0x01, 0x12, 0x0a,
+	0x0a, 0x06, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45,
+	0x4c, 0x45, 0x54, 0x45, 0x10, 0x03, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x45, 0x53, 0x54, 0x4f, 0x52,
+	0x45, 0x10, 0x04, 0x12, 0x0a, 0x0a, 0x06, 0x49, 0x4d, 0x50, 0x4f, 0x52, 0x54, 0x10, 0x05, 0x12,
+	0x09, 0x0a, 0x05, 0x50, 0x55, 0x52, 0x47, 0x45, 0x10, 0x06, 0x42, 0x04, 0x5a, 0x02, 0x70, 0x62,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_revision_message_proto_rawDescOnce sync.Once
+	file_revision_message_proto_rawDescData = file_revision_message_proto_rawDesc
+)
+
+func file_revision_message_proto_rawDescGZIP() []byte {
+	file_revision_message_proto_rawDescOnce.Do(func() {
+		file_revision_message_proto_rawDescData = protoimpl.X.CompressGZIP(file_revision_message_proto_rawDescData)
+	})
+	return file_revision_message_proto_rawDescData
+}
+
+var file_revision_message_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_revision_message_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_revision_message_proto_goTypes = []interface{}{
+	(LaptopRevision_Operation)(0), // 0: proto.LaptopRevision.Operation
+	(*LaptopRevision)(nil),        // 1: proto.LaptopRevision
+	(*timestamp.Timestamp)(nil),   // 2: google.protobuf.Timestamp
+	(*Laptop)(nil),                // 3: proto.Laptop
+}
+var file_revision_message_proto_depIdxs = []int32{
+	0, // 0: proto.LaptopRevision.operation:type_name -> proto.LaptopRevision.Operation
+	2, // 1: proto.LaptopRevision.timestamp:type_name -> google.protobuf.Timestamp
+	3, // 2: proto.LaptopRevision.laptop:type_name -> proto.Laptop
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_revision_message_proto_init() }
+func file_revision_message_proto_init() {
+	if File_revision_message_proto != nil {
+		return
+	}
+	file_laptop_message_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_revision_message_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LaptopRevision); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_revision_message_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_revision_message_proto_goTypes,
+		DependencyIndexes: file_revision_message_proto_depIdxs,
+		EnumInfos:         file_revision_message_proto_enumTypes,
+		MessageInfos:      file_revision_message_proto_msgTypes,
+	}.Build()
+	File_revision_message_proto = out.File
+	file_revision_message_proto_rawDesc = nil
+	file_revision_message_proto_goTypes = nil
+	file_revision_message_proto_depIdxs = nil
+}
@@ -1,7 +1,14 @@
 package sample
 
 import (
+	"bytes"
 	"demo-grpc/pb"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"math/rand"
 
 	"github.com/golang/protobuf/ptypes"
 )
@@ -119,3 +126,27 @@ func NewLaptop() *pb.Laptop {
 func RandomLaptopScore() float64 {
 	return float64(randomInt(1, 10))
 }
+
+//NewImage returns a new sample image with random pixels, of type ".jpg", ".png" or ".gif"
+func NewImage(imageType string, width int, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	rand.Read(img.Pix)
+
+	data := bytes.Buffer{}
+	var err error
+	switch imageType {
+	case ".jpg":
+		err = jpeg.Encode(&data, img, nil)
+	case ".png":
+		err = png.Encode(&data, img)
+	case ".gif":
+		err = gif.Encode(&data, img, nil)
+	default:
+		err = fmt.Errorf("unknown image type %s", imageType)
+	}
+	if err != nil {
+		panic(err)
+	}
+
+	return data.Bytes()
+}
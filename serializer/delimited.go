@@ -0,0 +1,62 @@
+package serializer
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/proto"
+)
+
+//maxDelimitedSize is the largest message ReadDelimited accepts
+const maxDelimitedSize = 64 << 20
+
+//WriteDelimited writes a protocol buffer message preceded by its size as a varint, so that several can share a stream.
+//This is the standard length-delimited format, which other protobuf tools can read, e.g. parseDelimitedFrom in Java
+func WriteDelimited(writer io.Writer, message proto.Message) error {
+	data, err := proto.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("Cannot marshal proto message to binary: %v", err)
+	}
+
+	record := make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+len(data))
+	n := binary.PutUvarint(record, uint64(len(data)))
+	record = append(record[:n], data...)
+
+	_, err = writer.Write(record)
+	if err != nil {
+		return fmt.Errorf("Cannot write delimited message: %v", err)
+	}
+
+	return nil
+}
+
+//ReadDelimited reads a message written by WriteDelimited. It returns io.EOF if the stream ends before the message,
+//and io.ErrUnexpectedEOF if it ends in the middle of it
+func ReadDelimited(reader *bufio.Reader, message proto.Message) error {
+	size, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return err
+	}
+
+	if size > maxDelimitedSize {
+		return fmt.Errorf("Delimited message of %d bytes is larger than %d bytes", size, maxDelimitedSize)
+	}
+
+	data := make([]byte, size)
+	_, err = io.ReadFull(reader, data)
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return err
+	}
+
+	err = proto.Unmarshal(data, message)
+	if err != nil {
+		return fmt.Errorf("Cannot unmarshal binary to proto message: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package serializer
+
+import (
+	"bufio"
+	"bytes"
+	"demo-grpc/pb"
+	"demo-grpc/sample"
+	"io"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestDelimited(t *testing.T) {
+	t.Parallel()
+
+	laptop1 := sample.NewLaptop()
+	laptop2 := sample.NewLaptop()
+
+	buffer := &bytes.Buffer{}
+	require.NoError(t, WriteDelimited(buffer, laptop1))
+	require.NoError(t, WriteDelimited(buffer, laptop2))
+	data := buffer.Bytes()
+
+	//each message is only preceded by its size, as other protobuf tools expect
+	expected := []byte{}
+	for _, laptop := range []*pb.Laptop{laptop1, laptop2} {
+		message, err := proto.Marshal(laptop)
+		require.NoError(t, err)
+		expected = protowire.AppendBytes(expected, message)
+	}
+	require.Equal(t, expected, data)
+
+	reader := bufio.NewReader(bytes.NewReader(data))
+	for _, expected := range []*pb.Laptop{laptop1, laptop2} {
+		laptop := &pb.Laptop{}
+		require.NoError(t, ReadDelimited(reader, laptop))
+		require.True(t, proto.Equal(expected, laptop))
+	}
+	require.Equal(t, io.EOF, ReadDelimited(reader, &pb.Laptop{}))
+
+	reader = bufio.NewReader(bytes.NewReader(data[:len(data)-3]))
+	require.NoError(t, ReadDelimited(reader, &pb.Laptop{}))
+	require.Equal(t, io.ErrUnexpectedEOF, ReadDelimited(reader, &pb.Laptop{}))
+}
@@ -1,6 +1,11 @@
 package serializer
 
 import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 )
@@ -16,3 +21,44 @@ func ProtobufToJSON(message proto.Message) (string, error) {
 	b, err := marshaler.Marshal(message)
 	return string(b), err
 }
+
+//WriteJSONLine writes a protocol buffer message as JSON on a single line, so that several can share a stream as NDJSON
+func WriteJSONLine(writer io.Writer, message proto.Message) error {
+	marshaler := protojson.MarshalOptions{
+		UseProtoNames: true,
+	}
+
+	data, err := marshaler.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("Cannot marshal proto message to JSON: %v", err)
+	}
+
+	_, err = writer.Write(append(data, '\n'))
+	if err != nil {
+		return fmt.Errorf("Cannot write JSON line: %v", err)
+	}
+
+	return nil
+}
+
+//ReadJSONLine reads a message written by WriteJSONLine, skipping blank lines.
+//It returns io.EOF if the stream ends before the message
+func ReadJSONLine(reader *bufio.Reader, message proto.Message) error {
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && (err != io.EOF || len(line) == 0) {
+			return err
+		}
+
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		err = protojson.Unmarshal(line, message)
+		if err != nil {
+			return fmt.Errorf("Cannot unmarshal JSON to proto message: %v", err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,37 @@
+package serializer
+
+import (
+	"bufio"
+	"bytes"
+	"demo-grpc/pb"
+	"demo-grpc/sample"
+	"io"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONLine(t *testing.T) {
+	t.Parallel()
+
+	laptop1 := sample.NewLaptop()
+	laptop2 := sample.NewLaptop()
+
+	buffer := &bytes.Buffer{}
+	require.NoError(t, WriteJSONLine(buffer, laptop1))
+	buffer.WriteString("\n")
+	require.NoError(t, WriteJSONLine(buffer, laptop2))
+	require.Equal(t, 3, bytes.Count(buffer.Bytes(), []byte("\n")))
+
+	reader := bufio.NewReader(bytes.NewReader(bytes.TrimSuffix(buffer.Bytes(), []byte("\n"))))
+	for _, expected := range []*pb.Laptop{laptop1, laptop2} {
+		laptop := &pb.Laptop{}
+		require.NoError(t, ReadJSONLine(reader, laptop))
+		require.True(t, proto.Equal(expected, laptop))
+	}
+	require.Equal(t, io.EOF, ReadJSONLine(reader, &pb.Laptop{}))
+
+	reader = bufio.NewReader(bytes.NewBufferString("{not json}\n"))
+	require.Error(t, ReadJSONLine(reader, &pb.Laptop{}))
+}
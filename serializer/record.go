@@ -0,0 +1,92 @@
+package serializer
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+
+	"google.golang.org/protobuf/proto"
+)
+
+//maxRecordSize is the largest message ReadChecksummedRecord accepts, a bigger size means the record is corrupted
+const maxRecordSize = 64 << 20
+
+//ErrChecksum is returned when a checksummed record does not match its checksum
+var ErrChecksum = errors.New("Checksum of record does not match")
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+//MarshalChecksummedRecord encodes a protocol buffer message preceded by its size as a varint and its CRC-32C checksum.
+//It is the record format of the logs and snapshots of the server, which must tell a torn or corrupted record
+func MarshalChecksummedRecord(message proto.Message) ([]byte, error) {
+	data, err := proto.Marshal(message)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot marshal proto message to binary: %v", err)
+	}
+
+	record := make([]byte, binary.MaxVarintLen64+4, binary.MaxVarintLen64+4+len(data))
+	n := binary.PutUvarint(record, uint64(len(data)))
+	binary.BigEndian.PutUint32(record[n:], crc32.Checksum(data, crcTable))
+	record = append(record[:n+4], data...)
+	return record, nil
+}
+
+//WriteChecksummedRecord writes a protocol buffer message preceded by its size and checksum, so that several can share a stream
+func WriteChecksummedRecord(writer io.Writer, message proto.Message) error {
+	record, err := MarshalChecksummedRecord(message)
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(record)
+	if err != nil {
+		return fmt.Errorf("Cannot write checksummed record: %v", err)
+	}
+
+	return nil
+}
+
+//ReadChecksummedRecord reads a message written by WriteChecksummedRecord. It returns io.EOF if the stream ends before the message,
+//io.ErrUnexpectedEOF if it ends in the middle of it, and ErrChecksum if the message is corrupted
+func ReadChecksummedRecord(reader *bufio.Reader, message proto.Message) error {
+	size, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return err
+	}
+
+	if size > maxRecordSize {
+		return ErrChecksum
+	}
+
+	var checksum [4]byte
+	_, err = io.ReadFull(reader, checksum[:])
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return err
+	}
+
+	data := make([]byte, size)
+	_, err = io.ReadFull(reader, data)
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return err
+	}
+
+	if crc32.Checksum(data, crcTable) != binary.BigEndian.Uint32(checksum[:]) {
+		return ErrChecksum
+	}
+
+	err = proto.Unmarshal(data, message)
+	if err != nil {
+		return fmt.Errorf("Cannot unmarshal binary to proto message: %v", err)
+	}
+
+	return nil
+}
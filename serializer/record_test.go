@@ -0,0 +1,43 @@
+package serializer
+
+import (
+	"bufio"
+	"bytes"
+	"demo-grpc/pb"
+	"demo-grpc/sample"
+	"io"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecksummedRecord(t *testing.T) {
+	t.Parallel()
+
+	laptop1 := sample.NewLaptop()
+	laptop2 := sample.NewLaptop()
+
+	buffer := &bytes.Buffer{}
+	require.NoError(t, WriteChecksummedRecord(buffer, laptop1))
+	require.NoError(t, WriteChecksummedRecord(buffer, laptop2))
+	data := buffer.Bytes()
+
+	reader := bufio.NewReader(bytes.NewReader(data))
+	for _, expected := range []*pb.Laptop{laptop1, laptop2} {
+		laptop := &pb.Laptop{}
+		require.NoError(t, ReadChecksummedRecord(reader, laptop))
+		require.True(t, proto.Equal(expected, laptop))
+	}
+	require.Equal(t, io.EOF, ReadChecksummedRecord(reader, &pb.Laptop{}))
+
+	reader = bufio.NewReader(bytes.NewReader(data[:len(data)-3]))
+	require.NoError(t, ReadChecksummedRecord(reader, &pb.Laptop{}))
+	require.Equal(t, io.ErrUnexpectedEOF, ReadChecksummedRecord(reader, &pb.Laptop{}))
+
+	corrupted := append([]byte{}, data...)
+	corrupted[len(corrupted)-1]++
+	reader = bufio.NewReader(bytes.NewReader(corrupted))
+	require.NoError(t, ReadChecksummedRecord(reader, &pb.Laptop{}))
+	require.Equal(t, ErrChecksum, ReadChecksummedRecord(reader, &pb.Laptop{}))
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"demo-grpc/pb"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//AdminServer is the server for the administration of the whole server state.
+//It works on the stores directly, the other servers must be given the same stores wrapped by its gate
+type AdminServer struct {
+	stores         *serverStores
+	gate           *WriteGate
+	snapshotFolder string
+}
+
+//NewAdminServer returns a new admin server that keeps its snapshots in snapshotFolder
+func NewAdminServer(
+	gate *WriteGate,
+	snapshotFolder string,
+	laptopStore LaptopStore,
+	userStore UserStore,
+	ratingStore RatingStore,
+	imageStore ImageStore,
+) *AdminServer {
+	return &AdminServer{
+		stores: &serverStores{
+			laptopStore: laptopStore,
+			userStore:   userStore,
+			ratingStore: ratingStore,
+			imageStore:  imageStore,
+		},
+		gate:           gate,
+		snapshotFolder: snapshotFolder,
+	}
+}
+
+//Snapshot is a unary RPC to write the laptops, ratings, users and image info to a new archive.
+//Writes are held back while the archive is written, so it holds the state of a single point in time.
+//The name defaults to the time of the snapshot, an existing snapshot is never overwritten.
+//The trash and the revisions of the laptops are not part of the snapshot
+func (server *AdminServer) Snapshot(
+	ctx context.Context,
+	req *pb.SnapshotRequest,
+) (*pb.SnapshotResponse, error) {
+
+	createdAt := time.Now().UTC()
+	name := req.GetName()
+	if name == "" {
+		name = createdAt.Format("20060102T150405Z")
+	}
+	log.Printf("Receive a snapshot request with name: %s", name)
+
+	path, err := server.snapshotPath(name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	unfreeze := server.gate.freeze()
+	defer unfreeze()
+
+	_, err = os.Stat(path)
+	if err == nil {
+		return nil, status.Errorf(codes.AlreadyExists, "Snapshot %s already exists", name)
+	}
+
+	res, err := writeSnapshot(ctx, path, server.stores, createdAt)
+	if err != nil {
+		if err := contextError(ctx); err != nil {
+			return nil, err
+		}
+		return nil, logError(status.Errorf(codes.Internal, "Cannot write snapshot: %v", err))
+	}
+	res.Name = name
+
+	log.Printf("Saved snapshot %s with %d laptops and %d users", name, res.Laptops, res.Users)
+	return res, nil
+}
+
+//Restore is a unary RPC to replace the laptops, ratings, users and image info with those of a snapshot.
+//Writes fail with Unavailable until the restore is done, reads keep being served and may see it half done.
+//A restore that fails midway leaves the state mixed, restoring the same snapshot again completes it.
+//Images whose files were deleted since the snapshot are not restored. The trash and the revisions
+//are left as they are, so they keep the state from after the snapshot
+func (server *AdminServer) Restore(
+	ctx context.Context,
+	req *pb.RestoreRequest,
+) (*pb.RestoreResponse, error) {
+
+	name := req.GetName()
+	log.Printf("Receive a restore request with name: %s", name)
+
+	path, err := server.snapshotPath(name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	snap, err := readSnapshot(path)
+	if os.IsNotExist(err) {
+		return nil, status.Errorf(codes.NotFound, "Snapshot %s is not found", name)
+	}
+	if err != nil {
+		return nil, logError(status.Errorf(codes.FailedPrecondition, "Cannot read snapshot: %v", err))
+	}
+
+	done, err := server.gate.beginRestore()
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "%v", err)
+	}
+	defer done()
+
+	//the restore is not tied to the request, stopping halfway would leave the state mixed
+	err = restoreSnapshot(context.Background(), server.stores, snap)
+	if err != nil {
+		return nil, logError(status.Errorf(codes.Internal, "Cannot restore snapshot: %v", err))
+	}
+
+	createdAt, err := ptypes.TimestampProto(snap.createdAt)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Cannot convert timestamp: %v", err)
+	}
+
+	log.Printf("Restored snapshot %s with %d laptops and %d users", name, len(snap.entries), len(snap.users))
+
+	res := &pb.RestoreResponse{
+		Laptops:   uint32(len(snap.entries)),
+		Users:     uint32(len(snap.users)),
+		CreatedAt: createdAt,
+	}
+	return res, nil
+}
+
+//snapshotPath returns the path of the archive of a snapshot, names are plain file names inside the snapshot folder
+func (server *AdminServer) snapshotPath(name string) (string, error) {
+	if name == "" || strings.HasPrefix(name, ".") || strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("Snapshot name must be a non-empty file name that does not start with a dot: %q", name)
+	}
+	return filepath.Join(server.snapshotFolder, name+snapshotExtension), nil
+}
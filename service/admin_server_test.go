@@ -0,0 +1,224 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"demo-grpc/pb"
+	"demo-grpc/sample"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newTestAdminServer(t *testing.T) (*AdminServer, *serverStores) {
+	folder, err := ioutil.TempDir("", "admin")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(folder) })
+
+	stores := &serverStores{
+		laptopStore: NewInMemoryLaptopStore(),
+		userStore:   NewInMemoryUserStore(),
+		ratingStore: NewInMemoryRatingStore(),
+		imageStore:  NewDiskImageStore(folder),
+	}
+
+	server := NewAdminServer(NewWriteGate(), folder+"/snapshot", stores.laptopStore, stores.userStore, stores.ratingStore, stores.imageStore)
+	return server, stores
+}
+
+func TestServerSnapshotRestore(t *testing.T) {
+	t.Parallel()
+
+	server, stores := newTestAdminServer(t)
+
+	kept := sample.NewLaptop()
+	removed := sample.NewLaptop()
+	require.NoError(t, stores.laptopStore.Save(kept))
+	require.NoError(t, stores.laptopStore.Save(removed))
+	_, err := stores.ratingStore.Add(kept.Id, 7)
+	require.NoError(t, err)
+	imageID, err := SaveImage(stores.imageStore, kept.Id, ".jpg", bytes.NewBuffer(sample.NewImage(".jpg", 8, 8)), 1<<20)
+	require.NoError(t, err)
+	goneImageID, err := SaveImage(stores.imageStore, kept.Id, ".png", bytes.NewBuffer(sample.NewImage(".png", 8, 8)), 1<<20)
+	require.NoError(t, err)
+
+	admin, err := NewUser("admin1", "secret", "admin")
+	require.NoError(t, err)
+	require.NoError(t, stores.userStore.Save(admin))
+
+	res, err := server.Snapshot(context.Background(), &pb.SnapshotRequest{Name: "before"})
+	require.NoError(t, err)
+	require.Equal(t, "before", res.GetName())
+	require.Equal(t, uint32(2), res.GetLaptops())
+	require.Equal(t, uint32(1), res.GetUsers())
+
+	_, err = server.Snapshot(context.Background(), &pb.SnapshotRequest{Name: "before"})
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	//a bad bulk import: a new laptop with an image, a changed and a deleted laptop, new ratings and users
+	added := sample.NewLaptop()
+	require.NoError(t, stores.laptopStore.Save(added))
+	addedImageID, err := SaveImage(stores.imageStore, added.Id, ".png", bytes.NewBuffer(sample.NewImage(".png", 8, 8)), 1<<20)
+	require.NoError(t, err)
+	changed := proto.Clone(kept).(*pb.Laptop)
+	changed.Name = "Changed"
+	_, err = stores.laptopStore.Update(changed, 0)
+	require.NoError(t, err)
+	require.NoError(t, stores.laptopStore.Delete(removed.Id, 0))
+	_, err = stores.ratingStore.Add(kept.Id, 1)
+	require.NoError(t, err)
+	_, err = stores.ratingStore.Add(removed.Id, 1)
+	require.NoError(t, err)
+	require.NoError(t, stores.imageStore.Delete(goneImageID))
+	require.NoError(t, stores.userStore.Delete("admin1"))
+	intruder, err := NewUser("intruder", "secret", "admin")
+	require.NoError(t, err)
+	require.NoError(t, stores.userStore.Save(intruder))
+
+	restored, err := server.Restore(context.Background(), &pb.RestoreRequest{Name: "before"})
+	require.NoError(t, err)
+	require.Equal(t, uint32(2), restored.GetLaptops())
+	require.Equal(t, uint32(1), restored.GetUsers())
+	require.Equal(t, res.GetCreatedAt().GetSeconds(), restored.GetCreatedAt().GetSeconds())
+
+	for _, laptop := range []*pb.Laptop{kept, removed} {
+		found, err := stores.laptopStore.Find(laptop.Id)
+		require.NoError(t, err)
+		requireSameLaptop(t, laptop, found)
+	}
+
+	found, err := stores.laptopStore.Find(added.Id)
+	require.NoError(t, err)
+	require.Nil(t, found)
+
+	rating, err := stores.ratingStore.Find(kept.Id)
+	require.NoError(t, err)
+	require.Equal(t, &Rating{Count: 1, Sum: 7}, rating)
+	rating, err = stores.ratingStore.Find(removed.Id)
+	require.NoError(t, err)
+	require.Nil(t, rating)
+
+	//image info is restored unless the file of the image is gone, the files of images uploaded after the snapshot are removed
+	images, err := stores.imageStore.List(kept.Id)
+	require.NoError(t, err)
+	require.Len(t, images, 1)
+	require.Equal(t, imageID, images[0].ID)
+	image, err := stores.imageStore.Open(imageID)
+	require.NoError(t, err)
+	require.NoError(t, image.Close())
+	gone, err := stores.imageStore.Find(goneImageID)
+	require.NoError(t, err)
+	require.Nil(t, gone)
+	images, err = stores.imageStore.List(added.Id)
+	require.NoError(t, err)
+	require.Empty(t, images)
+	require.Equal(t, ErrNotFound, stores.imageStore.Delete(addedImageID))
+
+	users, err := stores.userStore.List()
+	require.NoError(t, err)
+	require.Equal(t, []*User{admin}, users)
+}
+
+func TestServerRestoreRefusesWrites(t *testing.T) {
+	t.Parallel()
+
+	server, stores := newTestAdminServer(t)
+	_, err := server.Snapshot(context.Background(), &pb.SnapshotRequest{Name: "empty"})
+	require.NoError(t, err)
+
+	gate := server.gate
+	laptopServer := NewLaptopServer(
+		gate.LaptopStore(stores.laptopStore),
+		gate.ImageStore(stores.imageStore),
+		gate.RatingStore(stores.ratingStore),
+		nil,
+		nil,
+		nil,
+	)
+	_, ok := gate.LaptopStore(stores.laptopStore).(LaptopWatcher)
+	require.True(t, ok)
+
+	done, err := gate.beginRestore()
+	require.NoError(t, err)
+
+	_, err = laptopServer.CreateLaptop(context.Background(), &pb.CreateLaptopRequest{Laptop: sample.NewLaptop()})
+	require.Equal(t, codes.Unavailable, status.Code(err))
+
+	_, err = gate.RatingStore(stores.ratingStore).Add("laptop", 5)
+	require.Equal(t, ErrRestoring, err)
+
+	_, err = server.Restore(context.Background(), &pb.RestoreRequest{Name: "empty"})
+	require.Equal(t, codes.Unavailable, status.Code(err))
+
+	done()
+
+	_, err = laptopServer.CreateLaptop(context.Background(), &pb.CreateLaptopRequest{Laptop: sample.NewLaptop()})
+	require.NoError(t, err)
+}
+
+func TestServerSnapshotName(t *testing.T) {
+	t.Parallel()
+
+	server, _ := newTestAdminServer(t)
+
+	for _, name := range []string{"../outside", ".hidden", "a/b"} {
+		_, err := server.Snapshot(context.Background(), &pb.SnapshotRequest{Name: name})
+		require.Equal(t, codes.InvalidArgument, status.Code(err), name)
+	}
+
+	res, err := server.Snapshot(context.Background(), &pb.SnapshotRequest{})
+	require.NoError(t, err)
+	require.NotEmpty(t, res.GetName())
+
+	_, err = server.Restore(context.Background(), &pb.RestoreRequest{Name: "missing"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = server.Restore(context.Background(), &pb.RestoreRequest{Name: res.GetName()})
+	require.NoError(t, err)
+}
+
+func TestServerSnapshotDuringUpload(t *testing.T) {
+	t.Parallel()
+
+	server, stores := newTestAdminServer(t)
+	imageStore := server.gate.ImageStore(stores.imageStore)
+
+	//the upload stalls after its first bytes
+	image := sample.NewImage(".png", 8, 8)
+	reader, writer := io.Pipe()
+	saved := make(chan error)
+	go func() {
+		_, err := SaveImage(imageStore, "laptop", ".png", reader, 1<<20)
+		saved <- err
+	}()
+	_, err := writer.Write(image[:20])
+	require.NoError(t, err)
+
+	snapshotted := make(chan error)
+	go func() {
+		_, err := server.Snapshot(context.Background(), &pb.SnapshotRequest{Name: "upload"})
+		snapshotted <- err
+	}()
+	select {
+	case err := <-snapshotted:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "Snapshot waits for a stalled upload")
+	}
+
+	_, err = writer.Write(image[20:])
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	require.NoError(t, <-saved)
+
+	images, err := stores.imageStore.List("laptop")
+	require.NoError(t, err)
+	require.Len(t, images, 1)
+}
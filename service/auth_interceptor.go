@@ -16,6 +16,35 @@ type AuthInterceptor struct {
 	accessibleRoles map[string][]string
 }
 
+//claimsKey is the context key of the claims of the user who made a call
+type claimsKey struct{}
+
+//ClaimsFromContext returns the claims of the user who made a call.
+//They are only there for the RPCs that are restricted to some roles
+func ClaimsFromContext(ctx context.Context) (*UserClaims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*UserClaims)
+	return claims, ok
+}
+
+//callerName returns the username of the user who made a call, or an empty string if the call was not authorized
+func callerName(ctx context.Context) string {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok {
+		return ""
+	}
+	return claims.Username
+}
+
+//authorizedStream is a server stream whose context holds the claims of the user
+type authorizedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (stream *authorizedStream) Context() context.Context {
+	return stream.ctx
+}
+
 //NewAuthInterceptor returns a new auth interceptor
 func NewAuthInterceptor(jwtManager *JWTManager, accessibleRoles map[string][]string) *AuthInterceptor {
 	return &AuthInterceptor{
@@ -34,7 +63,7 @@ func (interceptor *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
 	) (interface{}, error) {
 		log.Println("----> unary interceptor", info.FullMethod)
 
-		err := interceptor.authorize(ctx, info.FullMethod)
+		ctx, err := interceptor.authorize(ctx, info.FullMethod)
 		if err != nil {
 			return nil, err
 		}
@@ -48,42 +77,43 @@ func (interceptor *AuthInterceptor) Stream() grpc.StreamServerInterceptor {
 	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
 		log.Println("----> stream interceptor", info.FullMethod)
 
-		err := interceptor.authorize(stream.Context(), info.FullMethod)
+		ctx, err := interceptor.authorize(stream.Context(), info.FullMethod)
 		if err != nil {
 			return err
 		}
 
-		return handler(srv, stream)
+		return handler(srv, &authorizedStream{ServerStream: stream, ctx: ctx})
 	}
 }
 
-func (interceptor *AuthInterceptor) authorize(ctx context.Context, method string) error {
+//authorize returns the context to handle the call with, which holds the claims of the user if the method needs a role
+func (interceptor *AuthInterceptor) authorize(ctx context.Context, method string) (context.Context, error) {
 	accessibleRoles, ok := interceptor.accessibleRoles[method]
 	if !ok {
-		return nil
+		return ctx, nil
 	}
 
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
-		return status.Errorf(codes.Unauthenticated, "Metadata is nor provided")
+		return nil, status.Errorf(codes.Unauthenticated, "Metadata is nor provided")
 	}
 
 	values := md["authorization"]
 	if len(values) == 0 {
-		return status.Errorf(codes.Unauthenticated, "Authorization token is not provided")
+		return nil, status.Errorf(codes.Unauthenticated, "Authorization token is not provided")
 	}
 
 	accessToken := values[0]
 	claims, err := interceptor.jwtManager.Verify(accessToken)
 	if err != nil {
-		return status.Errorf(codes.Unauthenticated, "Access token is invalid: %v", err)
+		return nil, status.Errorf(codes.Unauthenticated, "Access token is invalid: %v", err)
 	}
 
 	for _, role := range accessibleRoles {
 		if role == claims.Role {
-			return nil
+			return context.WithValue(ctx, claimsKey{}, claims), nil
 		}
 	}
 
-	return status.Errorf(codes.PermissionDenied, "User doesn't have permission to access this RPC")
+	return nil, status.Errorf(codes.PermissionDenied, "User doesn't have permission to access this RPC")
 }
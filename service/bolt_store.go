@@ -0,0 +1,1035 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"demo-grpc/pb"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	laptopBucket   = []byte("laptops")
+	userBucket     = []byte("users")
+	ratingBucket   = []byte("ratings")
+	imageBucket    = []byte("images")
+	revisionBucket = []byte("revisions")
+	trashBucket    = []byte("trash")
+	//retiredBucket holds the last version of each deleted laptop as 8 big-endian bytes, keyed by ID
+	retiredBucket = []byte("retired_laptops")
+	//laptopImageBucket indexes the images by laptop, keyed by laptop ID and image ID with an empty value
+	laptopImageBucket = []byte("laptop_images")
+)
+
+//OpenBoltDB opens the bolt database file at path, creating it if needed, with a bucket for each store
+func OpenBoltDB(path string) (*bolt.DB, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("Cannot open bolt database: %v", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		//a database made before the images were indexed gets its index built from the images it has
+		indexed := tx.Bucket(laptopImageBucket) != nil
+
+		for _, bucket := range [][]byte{laptopBucket, userBucket, ratingBucket, imageBucket, revisionBucket, trashBucket, retiredBucket, laptopImageBucket} {
+			_, err := tx.CreateBucketIfNotExists(bucket)
+			if err != nil {
+				return err
+			}
+		}
+
+		if indexed {
+			return nil
+		}
+		return tx.Bucket(imageBucket).ForEach(func(key, value []byte) error {
+			info := &ImageInfo{}
+			err := json.Unmarshal(value, info)
+			if err != nil {
+				return err
+			}
+			return tx.Bucket(laptopImageBucket).Put(laptopImageKey(info.LaptopID, info.ID), []byte{})
+		})
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("Cannot create bolt buckets: %v", err)
+	}
+
+	return db, nil
+}
+
+//BoltLaptopStore stores laptops in a bolt database, keyed by ID.
+//Each value is the version of the laptop as 8 big-endian bytes followed by the laptop in protobuf binary.
+//Writes hold the mutex until their change is published, so that watchers get the changes in commit order
+type BoltLaptopStore struct {
+	mutex    sync.RWMutex
+	db       *bolt.DB
+	watchers *laptopWatchers
+}
+
+//NewBoltLaptopStore returns a new BoltLaptopStore using a database opened with OpenBoltDB
+func NewBoltLaptopStore(db *bolt.DB) *BoltLaptopStore {
+	return &BoltLaptopStore{
+		db:       db,
+		watchers: newLaptopWatchers(),
+	}
+}
+
+func encodeLaptop(laptop *pb.Laptop, version uint64) ([]byte, error) {
+	data, err := proto.Marshal(laptop)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot marshal laptop: %v", err)
+	}
+
+	value := make([]byte, 8, 8+len(data))
+	binary.BigEndian.PutUint64(value, version)
+	return append(value, data...), nil
+}
+
+func decodeLaptop(value []byte) (*pb.Laptop, uint64, error) {
+	if len(value) < 8 {
+		return nil, 0, errors.New("Stored laptop is too short")
+	}
+
+	laptop := &pb.Laptop{}
+	err := proto.Unmarshal(value[8:], laptop)
+	if err != nil {
+		return nil, 0, fmt.Errorf("Cannot unmarshal laptop: %v", err)
+	}
+
+	return laptop, binary.BigEndian.Uint64(value), nil
+}
+
+//Save saves the laptop to store
+func (store *BoltLaptopStore) Save(laptop *pb.Laptop) error {
+	var version uint64
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	err := store.db.Update(func(tx *bolt.Tx) error {
+		var err error
+		version, err = insertBoltLaptop(tx, laptop)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	store.watchers.publish(pb.LaptopEvent_CREATED, nil, deepCopy(laptop), version)
+	return nil
+}
+
+//SaveAll saves every laptop in one transaction, or none of them if any cannot be saved
+func (store *BoltLaptopStore) SaveAll(laptops []*pb.Laptop) error {
+	versions := make([]uint64, len(laptops))
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	err := store.db.Update(func(tx *bolt.Tx) error {
+		for i, laptop := range laptops {
+			var err error
+			versions[i], err = insertBoltLaptop(tx, laptop)
+			if err != nil {
+				return fmt.Errorf("Cannot save laptop %s: %w", laptop.Id, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, laptop := range laptops {
+		store.watchers.publish(pb.LaptopEvent_CREATED, nil, deepCopy(laptop), versions[i])
+	}
+	return nil
+}
+
+//insertBoltLaptop puts a new laptop in the laptop bucket and returns its version,
+//which carries on from the last version of a deleted laptop with the same ID
+func insertBoltLaptop(tx *bolt.Tx, laptop *pb.Laptop) (uint64, error) {
+	bucket := tx.Bucket(laptopBucket)
+	if bucket.Get([]byte(laptop.Id)) != nil {
+		return 0, ErrAlreadyExists
+	}
+
+	version := firstVersion
+	retired := tx.Bucket(retiredBucket)
+	if last := retired.Get([]byte(laptop.Id)); len(last) == 8 {
+		version = binary.BigEndian.Uint64(last) + 1
+	}
+
+	value, err := encodeLaptop(laptop, version)
+	if err != nil {
+		return 0, err
+	}
+
+	err = bucket.Put([]byte(laptop.Id), value)
+	if err != nil {
+		return 0, err
+	}
+	return version, retired.Delete([]byte(laptop.Id))
+}
+
+//Find finds a laptop by ID
+func (store *BoltLaptopStore) Find(id string) (*pb.Laptop, error) {
+	laptop, _, err := store.FindWithVersion(id)
+	return laptop, err
+}
+
+//FindWithVersion finds a laptop by ID and returns it together with its current version
+func (store *BoltLaptopStore) FindWithVersion(id string) (*pb.Laptop, uint64, error) {
+	var laptop *pb.Laptop
+	var version uint64
+
+	err := store.db.View(func(tx *bolt.Tx) error {
+		value := tx.Bucket(laptopBucket).Get([]byte(id))
+		if value == nil {
+			return nil
+		}
+
+		var err error
+		laptop, version, err = decodeLaptop(value)
+		return err
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return laptop, version, nil
+}
+
+//Update replaces the stored laptop that has the same ID and returns its new version.
+//If expectedVersion is not zero, it must match the stored version
+func (store *BoltLaptopStore) Update(laptop *pb.Laptop, expectedVersion uint64) (uint64, error) {
+	var old *pb.Laptop
+	var version uint64
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	err := store.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(laptopBucket)
+		value := bucket.Get([]byte(laptop.Id))
+		if value == nil {
+			return ErrNotFound
+		}
+
+		var err error
+		old, version, err = decodeLaptop(value)
+		if err != nil {
+			return err
+		}
+		if expectedVersion != 0 && version != expectedVersion {
+			return ErrVersionMismatch
+		}
+		version++
+
+		value, err = encodeLaptop(laptop, version)
+		if err != nil {
+			return err
+		}
+		return bucket.Put([]byte(laptop.Id), value)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	store.watchers.publish(pb.LaptopEvent_UPDATED, old, deepCopy(laptop), version)
+	return version, nil
+}
+
+//Delete removes a laptop by ID. If expectedVersion is not zero, it must match the stored version
+func (store *BoltLaptopStore) Delete(id string, expectedVersion uint64) error {
+	var old *pb.Laptop
+	var version uint64
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	err := store.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(laptopBucket)
+		value := bucket.Get([]byte(id))
+		if value == nil {
+			return ErrNotFound
+		}
+
+		var err error
+		old, version, err = decodeLaptop(value)
+		if err != nil {
+			return err
+		}
+		if expectedVersion != 0 && version != expectedVersion {
+			return ErrVersionMismatch
+		}
+
+		last := make([]byte, 8)
+		binary.BigEndian.PutUint64(last, version)
+		err = tx.Bucket(retiredBucket).Put([]byte(id), last)
+		if err != nil {
+			return err
+		}
+		return bucket.Delete([]byte(id))
+	})
+	if err != nil {
+		return err
+	}
+
+	store.watchers.publish(pb.LaptopEvent_DELETED, old, nil, version)
+	return nil
+}
+
+//List returns up to limit laptops in ascending ID order, starting right after afterID.
+//An empty afterID starts from the beginning of the store
+func (store *BoltLaptopStore) List(ctx context.Context, afterID string, limit int) ([]*pb.Laptop, error) {
+	laptops := make([]*pb.Laptop, 0, limit)
+
+	err := store.db.View(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(laptopBucket).Cursor()
+		for key, value := cursor.Seek([]byte(afterID)); key != nil && len(laptops) < limit; key, value = cursor.Next() {
+			if string(key) == afterID {
+				continue
+			}
+
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			laptop, _, err := decodeLaptop(value)
+			if err != nil {
+				return err
+			}
+			laptops = append(laptops, laptop)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return laptops, nil
+}
+
+//Search searches for laptop with filter and the query of options, returns one by one via the found function.
+//Laptops are returned in the order given by options, and no more than its MaxResults if that is set
+func (store *BoltLaptopStore) Search(
+	ctx context.Context,
+	filter *pb.Filter,
+	options *SearchOptions,
+	found func(laptop *pb.Laptop) error,
+) error {
+	query := CompileFilter(filter).And(options.query())
+	laptops := []*pb.Laptop{}
+
+	//unless the results are sorted or ranked, the scan can stop as soon as there are enough of them
+	limit := options.maxResults()
+	if options.sorted() || options.text() != "" {
+		limit = 0
+	}
+
+	err := store.db.View(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(laptopBucket).Cursor()
+		for key, value := cursor.First(); key != nil; key, value = cursor.Next() {
+			if ctx.Err() == context.Canceled || ctx.Err() == context.DeadlineExceeded {
+				return errors.New("Context is cancelled")
+			}
+
+			laptop, _, err := decodeLaptop(value)
+			if err != nil {
+				return err
+			}
+
+			if query.Match(laptop) {
+				laptops = append(laptops, laptop)
+				if len(laptops) == limit {
+					return nil
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, laptop := range options.arrange(laptops) {
+		err := found(laptop)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//Watch sends the laptops matching the filter first if initial is true, then every change to a laptop
+//that matches the filter before or after the change, until ctx is done or found returns an error.
+//The changes are buffered for each watcher, a watcher too slow to keep up gets ErrWatcherTooSlow
+func (store *BoltLaptopStore) Watch(
+	ctx context.Context,
+	filter *pb.Filter,
+	initial bool,
+	found func(event *pb.LaptopEvent) error,
+) error {
+	query := CompileFilter(filter)
+
+	//the watcher is added under the read lock, so no change is missed or sent twice between the initial laptops and the events
+	store.mutex.RLock()
+	w := store.watchers.add(query)
+	defer store.watchers.remove(w)
+
+	events := []*pb.LaptopEvent{}
+	var err error
+	if initial {
+		events, err = store.existing(query)
+	}
+	store.mutex.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	return w.run(ctx, events, found)
+}
+
+//existing returns an EXISTING event for each stored laptop that matches the query, in ascending ID order
+func (store *BoltLaptopStore) existing(query *Query) ([]*pb.LaptopEvent, error) {
+	events := []*pb.LaptopEvent{}
+	err := store.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(laptopBucket).ForEach(func(key, value []byte) error {
+			laptop, version, err := decodeLaptop(value)
+			if err != nil {
+				return err
+			}
+
+			if query.Match(laptop) {
+				events = append(events, &pb.LaptopEvent{
+					Type:    pb.LaptopEvent_EXISTING,
+					Laptop:  laptop,
+					Version: version,
+				})
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+//BoltUserStore stores users in a bolt database, keyed by username
+type BoltUserStore struct {
+	db *bolt.DB
+}
+
+//NewBoltUserStore returns a new BoltUserStore using a database opened with OpenBoltDB
+func NewBoltUserStore(db *bolt.DB) *BoltUserStore {
+	return &BoltUserStore{
+		db: db,
+	}
+}
+
+//Save saves a user to the store
+func (store *BoltUserStore) Save(user *User) error {
+	value, err := json.Marshal(user)
+	if err != nil {
+		return fmt.Errorf("Cannot marshal user: %v", err)
+	}
+
+	return store.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(userBucket)
+		if bucket.Get([]byte(user.Username)) != nil {
+			return ErrAlreadyExists
+		}
+		return bucket.Put([]byte(user.Username), value)
+	})
+}
+
+//Find finds a user by username
+func (store *BoltUserStore) Find(username string) (*User, error) {
+	var user *User
+
+	err := store.db.View(func(tx *bolt.Tx) error {
+		value := tx.Bucket(userBucket).Get([]byte(username))
+		if value == nil {
+			return nil
+		}
+
+		user = &User{}
+		return json.Unmarshal(value, user)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Cannot find user: %v", err)
+	}
+
+	return user, nil
+}
+
+//List returns every user, ordered by username
+func (store *BoltUserStore) List() ([]*User, error) {
+	users := []*User{}
+
+	err := store.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(userBucket).ForEach(func(key, value []byte) error {
+			user := &User{}
+			err := json.Unmarshal(value, user)
+			if err != nil {
+				return err
+			}
+
+			users = append(users, user)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Cannot list users: %v", err)
+	}
+
+	return users, nil
+}
+
+//Delete removes a user by username
+func (store *BoltUserStore) Delete(username string) error {
+	return store.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(userBucket)
+		if bucket.Get([]byte(username)) == nil {
+			return ErrNotFound
+		}
+		return bucket.Delete([]byte(username))
+	})
+}
+
+//BoltRatingStore stores laptop ratings in a bolt database, keyed by laptop ID
+type BoltRatingStore struct {
+	db *bolt.DB
+}
+
+//NewBoltRatingStore returns a new BoltRatingStore using a database opened with OpenBoltDB
+func NewBoltRatingStore(db *bolt.DB) *BoltRatingStore {
+	return &BoltRatingStore{
+		db: db,
+	}
+}
+
+//Add adds a new laptop score to the store and returns its rating
+func (store *BoltRatingStore) Add(laptopID string, score float64) (*Rating, error) {
+	rating := &Rating{}
+
+	err := store.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(ratingBucket)
+		if value := bucket.Get([]byte(laptopID)); value != nil {
+			err := json.Unmarshal(value, rating)
+			if err != nil {
+				return err
+			}
+		}
+
+		rating.Count++
+		rating.Sum += score
+
+		value, err := json.Marshal(rating)
+		if err != nil {
+			return err
+		}
+		return bucket.Put([]byte(laptopID), value)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Cannot add rating: %v", err)
+	}
+
+	return rating, nil
+}
+
+//Find returns the rating of a laptop, or nil if it has never been rated
+func (store *BoltRatingStore) Find(laptopID string) (*Rating, error) {
+	var rating *Rating
+
+	err := store.db.View(func(tx *bolt.Tx) error {
+		value := tx.Bucket(ratingBucket).Get([]byte(laptopID))
+		if value == nil {
+			return nil
+		}
+
+		rating = &Rating{}
+		return json.Unmarshal(value, rating)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Cannot find rating: %v", err)
+	}
+
+	return rating, nil
+}
+
+//Set replaces the rating of a laptop
+func (store *BoltRatingStore) Set(laptopID string, rating *Rating) error {
+	value, err := json.Marshal(rating)
+	if err != nil {
+		return fmt.Errorf("Cannot marshal rating: %v", err)
+	}
+
+	return store.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(ratingBucket).Put([]byte(laptopID), value)
+	})
+}
+
+//Delete removes the rating of a laptop, if it has one
+func (store *BoltRatingStore) Delete(laptopID string) error {
+	return store.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(ratingBucket).Delete([]byte(laptopID))
+	})
+}
+
+//BoltImageStore stores images on disk and their info in a bolt database, keyed by image ID.
+//The images are also indexed by laptop, so that listing the images of a laptop only reads those
+type BoltImageStore struct {
+	db          *bolt.DB
+	imageFolder string
+}
+
+//NewBoltImageStore returns a new BoltImageStore using a database opened with OpenBoltDB
+func NewBoltImageStore(db *bolt.DB, imageFolder string) *BoltImageStore {
+	return &BoltImageStore{
+		db:          db,
+		imageFolder: imageFolder,
+	}
+}
+
+//Stage writes a new image to a temp file of the image folder
+func (store *BoltImageStore) Stage(imageType string, imageData io.Reader, maxSize int64) (*StagedImage, error) {
+	return stageImageFile(store.imageFolder, imageType, imageData, maxSize)
+}
+
+//Save saves a new laptop image to the store
+func (store *BoltImageStore) Save(laptopID string, staged *StagedImage) (string, error) {
+	imageID, err := uuid.NewRandom()
+	if err != nil {
+		return "", fmt.Errorf("Cannot generate image id: %v", err)
+	}
+
+	info, err := placeStagedImage(store.imageFolder, imageID.String(), staged)
+	if err != nil {
+		return "", err
+	}
+	info.ID = imageID.String()
+	info.LaptopID = laptopID
+
+	err = store.db.Update(func(tx *bolt.Tx) error {
+		return putImageInfo(tx, info)
+	})
+	if err != nil {
+		os.Remove(info.Path)
+		return "", fmt.Errorf("Cannot save image info: %v", err)
+	}
+
+	return imageID.String(), nil
+}
+
+//SaveInfo records the info of an image whose file is already in place, replacing any info with the same ID.
+//The file is the one the store would write for the ID and type of the image, whatever the path of the info
+func (store *BoltImageStore) SaveInfo(info *ImageInfo) error {
+	placed, err := placeImageInfo(store.imageFolder, info)
+	if err != nil {
+		return err
+	}
+
+	return store.db.Update(func(tx *bolt.Tx) error {
+		return putImageInfo(tx, placed)
+	})
+}
+
+//putImageInfo records the info of an image and indexes it under its laptop,
+//in place of any info with the same ID, which may be of another laptop
+func putImageInfo(tx *bolt.Tx, info *ImageInfo) error {
+	value, err := json.Marshal(info)
+	if err != nil {
+		return fmt.Errorf("Cannot marshal image info: %v", err)
+	}
+
+	bucket := tx.Bucket(imageBucket)
+	index := tx.Bucket(laptopImageBucket)
+	if old := bucket.Get([]byte(info.ID)); old != nil {
+		oldInfo := &ImageInfo{}
+		err = json.Unmarshal(old, oldInfo)
+		if err != nil {
+			return err
+		}
+		err = index.Delete(laptopImageKey(oldInfo.LaptopID, info.ID))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = index.Put(laptopImageKey(info.LaptopID, info.ID), []byte{})
+	if err != nil {
+		return err
+	}
+	return bucket.Put([]byte(info.ID), value)
+}
+
+//laptopImageKey returns the key of an image in the laptop index, which starts with the prefix of its laptop
+func laptopImageKey(laptopID string, imageID string) []byte {
+	return append(laptopImagePrefix(laptopID), imageID...)
+}
+
+//laptopImagePrefix returns the prefix of the keys of the images of a laptop in the laptop index.
+//Image IDs are UUIDs, so the separator keeps the images of a laptop apart from those of a laptop with a longer ID
+func laptopImagePrefix(laptopID string) []byte {
+	return []byte(laptopID + "/")
+}
+
+//SaveVariant saves a variant of an image next to the image
+func (store *BoltImageStore) SaveVariant(imageID string, maxSide int, staged *StagedImage) error {
+	file, err := placeStagedImage(store.imageFolder, variantFileName(imageID, maxSide), staged)
+	if err != nil {
+		return err
+	}
+	variant := newImageVariant(file, maxSide)
+
+	var old *ImageVariant
+	err = store.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(imageBucket)
+		value := bucket.Get([]byte(imageID))
+		if value == nil {
+			return ErrNotFound
+		}
+
+		info := &ImageInfo{}
+		err := json.Unmarshal(value, info)
+		if err != nil {
+			return err
+		}
+		old = info.Variant(maxSide)
+		info.Variants = withVariant(info.Variants, variant)
+
+		value, err = json.Marshal(info)
+		if err != nil {
+			return err
+		}
+		return bucket.Put([]byte(imageID), value)
+	})
+	if err == ErrNotFound {
+		removeImageFile(variant.Path)
+		return err
+	}
+	if err != nil {
+		removeImageFile(variant.Path)
+		return fmt.Errorf("Cannot save variant info: %v", err)
+	}
+
+	//a replaced variant of another type is in another file
+	if old != nil && old.Path != variant.Path {
+		removeImageFile(old.Path)
+	}
+	return nil
+}
+
+//Find returns the info of an image by ID, or nil if there is no such image
+func (store *BoltImageStore) Find(imageID string) (*ImageInfo, error) {
+	var info *ImageInfo
+
+	err := store.db.View(func(tx *bolt.Tx) error {
+		value := tx.Bucket(imageBucket).Get([]byte(imageID))
+		if value == nil {
+			return nil
+		}
+
+		info = &ImageInfo{}
+		return json.Unmarshal(value, info)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Cannot find image: %v", err)
+	}
+
+	return info, nil
+}
+
+//Open opens the file of an image by ID for reading, the caller must close it
+func (store *BoltImageStore) Open(imageID string) (io.ReadCloser, error) {
+	info, err := store.Find(imageID)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, ErrNotFound
+	}
+
+	return openImageFile(info.Path)
+}
+
+//OpenVariant opens the file of a variant of an image for reading, the caller must close it
+func (store *BoltImageStore) OpenVariant(imageID string, maxSide int) (io.ReadCloser, error) {
+	info, err := store.Find(imageID)
+	if err != nil {
+		return nil, err
+	}
+
+	return openVariantFile(info, maxSide)
+}
+
+//List returns the info of the images of a laptop, ordered by image ID
+func (store *BoltImageStore) List(laptopID string) ([]*ImageInfo, error) {
+	images := []*ImageInfo{}
+
+	err := store.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(imageBucket)
+		prefix := laptopImagePrefix(laptopID)
+
+		cursor := tx.Bucket(laptopImageBucket).Cursor()
+		for key, _ := cursor.Seek(prefix); key != nil && bytes.HasPrefix(key, prefix); key, _ = cursor.Next() {
+			value := bucket.Get(key[len(prefix):])
+			if value == nil {
+				continue
+			}
+
+			info := &ImageInfo{}
+			err := json.Unmarshal(value, info)
+			if err != nil {
+				return err
+			}
+			images = append(images, info)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Cannot list images: %v", err)
+	}
+
+	return images, nil
+}
+
+//Delete removes an image by ID, together with its file and the files of its variants
+func (store *BoltImageStore) Delete(imageID string) error {
+	return store.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(imageBucket)
+		value := bucket.Get([]byte(imageID))
+		if value == nil {
+			return ErrNotFound
+		}
+
+		info := &ImageInfo{}
+		err := json.Unmarshal(value, info)
+		if err != nil {
+			return err
+		}
+
+		err = bucket.Delete([]byte(imageID))
+		if err != nil {
+			return err
+		}
+		err = tx.Bucket(laptopImageBucket).Delete(laptopImageKey(info.LaptopID, imageID))
+		if err != nil {
+			return err
+		}
+
+		//the files are removed last, so that a failure leaves both the info and the files in place
+		err = removeVariantFiles(info)
+		if err != nil {
+			return err
+		}
+		return removeImageFile(info.Path)
+	})
+}
+
+//BoltRevisionStore stores laptop revisions in a bolt database.
+//Each laptop has its own nested bucket, keyed by the revision number as 8 big-endian bytes
+type BoltRevisionStore struct {
+	db *bolt.DB
+}
+
+//NewBoltRevisionStore returns a new BoltRevisionStore using a database opened with OpenBoltDB
+func NewBoltRevisionStore(db *bolt.DB) *BoltRevisionStore {
+	return &BoltRevisionStore{
+		db: db,
+	}
+}
+
+func revisionKey(revision uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, revision)
+	return key
+}
+
+//Append stores the revision and returns its number
+func (store *BoltRevisionStore) Append(revision *pb.LaptopRevision) (uint64, error) {
+	var number uint64
+
+	err := store.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.Bucket(revisionBucket).CreateBucketIfNotExists([]byte(revision.GetLaptopId()))
+		if err != nil {
+			return err
+		}
+
+		number, err = bucket.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		other := proto.Clone(revision).(*pb.LaptopRevision)
+		other.Revision = number
+
+		value, err := proto.Marshal(other)
+		if err != nil {
+			return fmt.Errorf("Cannot marshal revision: %v", err)
+		}
+		return bucket.Put(revisionKey(number), value)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return number, nil
+}
+
+//Find returns a revision of a laptop, or nil if there is no such revision
+func (store *BoltRevisionStore) Find(laptopID string, revision uint64) (*pb.LaptopRevision, error) {
+	var found *pb.LaptopRevision
+
+	err := store.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(revisionBucket).Bucket([]byte(laptopID))
+		if bucket == nil {
+			return nil
+		}
+
+		value := bucket.Get(revisionKey(revision))
+		if value == nil {
+			return nil
+		}
+
+		found = &pb.LaptopRevision{}
+		return proto.Unmarshal(value, found)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Cannot find revision: %v", err)
+	}
+
+	return found, nil
+}
+
+//List returns up to limit revisions of a laptop in ascending order, starting right after afterRevision
+func (store *BoltRevisionStore) List(laptopID string, afterRevision uint64, limit int) ([]*pb.LaptopRevision, error) {
+	revisions := []*pb.LaptopRevision{}
+
+	err := store.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(revisionBucket).Bucket([]byte(laptopID))
+		if bucket == nil {
+			return nil
+		}
+
+		cursor := bucket.Cursor()
+		for key, value := cursor.Seek(revisionKey(afterRevision + 1)); key != nil && len(revisions) < limit; key, value = cursor.Next() {
+			revision := &pb.LaptopRevision{}
+			err := proto.Unmarshal(value, revision)
+			if err != nil {
+				return err
+			}
+			revisions = append(revisions, revision)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Cannot list revisions: %v", err)
+	}
+
+	return revisions, nil
+}
+
+//BoltTrashStore stores soft-deleted laptops in a bolt database, keyed by ID
+type BoltTrashStore struct {
+	db *bolt.DB
+}
+
+//NewBoltTrashStore returns a new BoltTrashStore using a database opened with OpenBoltDB
+func NewBoltTrashStore(db *bolt.DB) *BoltTrashStore {
+	return &BoltTrashStore{
+		db: db,
+	}
+}
+
+//Put moves the laptop to the trash, there can only be one laptop with the same ID
+func (store *BoltTrashStore) Put(laptop *pb.TrashedLaptop) error {
+	value, err := proto.Marshal(laptop)
+	if err != nil {
+		return fmt.Errorf("Cannot marshal trashed laptop: %v", err)
+	}
+
+	id := []byte(laptop.GetLaptop().GetId())
+	return store.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(trashBucket)
+		if bucket.Get(id) != nil {
+			return ErrAlreadyExists
+		}
+		return bucket.Put(id, value)
+	})
+}
+
+//Find returns a laptop of the trash by ID, or nil if it is not in the trash
+func (store *BoltTrashStore) Find(id string) (*pb.TrashedLaptop, error) {
+	var laptop *pb.TrashedLaptop
+
+	err := store.db.View(func(tx *bolt.Tx) error {
+		value := tx.Bucket(trashBucket).Get([]byte(id))
+		if value == nil {
+			return nil
+		}
+
+		laptop = &pb.TrashedLaptop{}
+		return proto.Unmarshal(value, laptop)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Cannot find trashed laptop: %v", err)
+	}
+
+	return laptop, nil
+}
+
+//Remove takes a laptop out of the trash by ID
+func (store *BoltTrashStore) Remove(id string) error {
+	return store.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(trashBucket)
+		if bucket.Get([]byte(id)) == nil {
+			return ErrNotFound
+		}
+		return bucket.Delete([]byte(id))
+	})
+}
+
+//List returns up to limit laptops of the trash in ascending ID order, starting right after afterID
+func (store *BoltTrashStore) List(afterID string, limit int) ([]*pb.TrashedLaptop, error) {
+	laptops := []*pb.TrashedLaptop{}
+
+	err := store.db.View(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(trashBucket).Cursor()
+		for key, value := cursor.Seek([]byte(afterID)); key != nil && len(laptops) < limit; key, value = cursor.Next() {
+			if string(key) == afterID {
+				continue
+			}
+
+			laptop := &pb.TrashedLaptop{}
+			err := proto.Unmarshal(value, laptop)
+			if err != nil {
+				return err
+			}
+			laptops = append(laptops, laptop)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Cannot list trashed laptops: %v", err)
+	}
+
+	return laptops, nil
+}
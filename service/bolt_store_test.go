@@ -0,0 +1,237 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"demo-grpc/pb"
+	"demo-grpc/sample"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestBoltStores(t *testing.T) {
+	t.Parallel()
+
+	folder, err := ioutil.TempDir("", "bolt")
+	require.NoError(t, err)
+	defer os.RemoveAll(folder)
+
+	path := filepath.Join(folder, "data.db")
+	db, err := OpenBoltDB(path)
+	require.NoError(t, err)
+
+	laptopStore := NewBoltLaptopStore(db)
+	laptops := []*pb.Laptop{}
+	for i := 0; i < 5; i++ {
+		laptop := sample.NewLaptop()
+		require.NoError(t, laptopStore.Save(laptop))
+		laptops = append(laptops, laptop)
+	}
+	require.Equal(t, ErrAlreadyExists, laptopStore.Save(laptops[0]))
+
+	laptops[1].Name = "Updated"
+	version, err := laptopStore.Update(laptops[1], firstVersion)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), version)
+	_, err = laptopStore.Update(laptops[1], firstVersion)
+	require.Equal(t, ErrVersionMismatch, err)
+	require.NoError(t, laptopStore.Delete(laptops[2].Id, 0))
+
+	userStore := NewBoltUserStore(db)
+	user, err := NewUser("admin", "secret", "admin")
+	require.NoError(t, err)
+	require.NoError(t, userStore.Save(user))
+	require.Equal(t, ErrAlreadyExists, userStore.Save(user))
+
+	ratingStore := NewBoltRatingStore(db)
+	_, err = ratingStore.Add(laptops[0].Id, 4)
+	require.NoError(t, err)
+	rating, err := ratingStore.Add(laptops[0].Id, 5)
+	require.NoError(t, err)
+	require.Equal(t, &Rating{Count: 2, Sum: 9}, rating)
+
+	imageStore := NewBoltImageStore(db, folder)
+	imageID, err := SaveImage(imageStore, laptops[0].Id, ".jpg", bytes.NewBuffer(sample.NewImage(".jpg", 8, 8)), 1<<20)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	//everything must still be there after the database is opened again
+	db, err = OpenBoltDB(path)
+	require.NoError(t, err)
+	defer db.Close()
+
+	laptopStore = NewBoltLaptopStore(db)
+	for i, laptop := range laptops {
+		found, version, err := laptopStore.FindWithVersion(laptop.Id)
+		require.NoError(t, err)
+
+		switch i {
+		case 1:
+			require.True(t, proto.Equal(laptop, found))
+			require.Equal(t, uint64(2), version)
+		case 2:
+			require.Nil(t, found)
+		default:
+			require.True(t, proto.Equal(laptop, found))
+			require.Equal(t, firstVersion, version)
+		}
+	}
+
+	found := 0
+	err = laptopStore.Search(context.Background(), nil, &SearchOptions{MaxResults: 3}, func(laptop *pb.Laptop) error {
+		found++
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, found)
+
+	listed, err := laptopStore.List(context.Background(), "", 10)
+	require.NoError(t, err)
+	require.Len(t, listed, 4)
+	for i := 1; i < len(listed); i++ {
+		require.Less(t, listed[i-1].Id, listed[i].Id)
+	}
+
+	other, err := NewBoltUserStore(db).Find("admin")
+	require.NoError(t, err)
+	require.Equal(t, user, other)
+
+	rating, err = NewBoltRatingStore(db).Find(laptops[0].Id)
+	require.NoError(t, err)
+	require.Equal(t, &Rating{Count: 2, Sum: 9}, rating)
+
+	err = db.View(func(tx *bolt.Tx) error {
+		require.NotNil(t, tx.Bucket(imageBucket).Get([]byte(imageID)))
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestBoltLaptopStoreWatch(t *testing.T) {
+	t.Parallel()
+
+	folder, err := ioutil.TempDir("", "bolt")
+	require.NoError(t, err)
+	defer os.RemoveAll(folder)
+
+	db, err := OpenBoltDB(filepath.Join(folder, "data.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	store := NewBoltLaptopStore(db)
+	existing := sample.NewLaptop()
+	require.NoError(t, store.Save(existing))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := make(chan *pb.LaptopEvent, 10)
+	done := make(chan error)
+	go func() {
+		done <- store.Watch(ctx, nil, true, func(event *pb.LaptopEvent) error {
+			events <- event
+			return nil
+		})
+	}()
+
+	event := <-events
+	require.Equal(t, pb.LaptopEvent_EXISTING, event.GetType())
+	require.True(t, proto.Equal(existing, event.GetLaptop()))
+	require.Equal(t, firstVersion, event.GetVersion())
+
+	created := sample.NewLaptop()
+	require.NoError(t, store.Save(created))
+	created.Name = "Updated"
+	_, err = store.Update(created, firstVersion)
+	require.NoError(t, err)
+	require.NoError(t, store.Delete(existing.Id, 0))
+
+	expected := []struct {
+		eventType pb.LaptopEvent_Type
+		laptop    *pb.Laptop
+		version   uint64
+	}{
+		{pb.LaptopEvent_CREATED, created, firstVersion},
+		{pb.LaptopEvent_UPDATED, created, firstVersion + 1},
+		{pb.LaptopEvent_DELETED, existing, firstVersion},
+	}
+	for _, want := range expected {
+		event := <-events
+		require.Equal(t, want.eventType, event.GetType())
+		require.Equal(t, want.laptop.Id, event.GetLaptop().GetId())
+		require.Equal(t, want.version, event.GetVersion())
+		if want.eventType == pb.LaptopEvent_UPDATED {
+			require.Equal(t, "Updated", event.GetLaptop().GetName())
+		}
+	}
+
+	cancel()
+	require.Error(t, <-done)
+}
+
+func TestBoltImageStoreIndex(t *testing.T) {
+	t.Parallel()
+
+	folder, err := ioutil.TempDir("", "bolt")
+	require.NoError(t, err)
+	defer os.RemoveAll(folder)
+
+	path := filepath.Join(folder, "data.db")
+	db, err := OpenBoltDB(path)
+	require.NoError(t, err)
+
+	//a laptop ID that starts with another one does not share its images
+	store := NewBoltImageStore(db, folder)
+	ids := map[string][]string{}
+	for _, laptopID := range []string{"laptop", "laptop2", "laptop"} {
+		imageID, err := SaveImage(store, laptopID, ".png", bytes.NewBuffer(sample.NewImage(".png", 8, 8)), 1<<20)
+		require.NoError(t, err)
+		ids[laptopID] = append(ids[laptopID], imageID)
+	}
+
+	listIDs := func(laptopID string) []string {
+		images, err := store.List(laptopID)
+		require.NoError(t, err)
+
+		listed := []string{}
+		for _, image := range images {
+			require.Equal(t, laptopID, image.LaptopID)
+			listed = append(listed, image.ID)
+		}
+		return listed
+	}
+	require.ElementsMatch(t, ids["laptop"], listIDs("laptop"))
+	require.Equal(t, ids["laptop2"], listIDs("laptop2"))
+	require.Empty(t, listIDs("lap"))
+
+	//an image whose info moves to another laptop is only listed there
+	moved, err := store.Find(ids["laptop"][0])
+	require.NoError(t, err)
+	moved.LaptopID = "laptop2"
+	require.NoError(t, store.SaveInfo(moved))
+	require.Equal(t, ids["laptop"][1:], listIDs("laptop"))
+	require.ElementsMatch(t, append(ids["laptop2"], moved.ID), listIDs("laptop2"))
+
+	require.NoError(t, store.Delete(ids["laptop2"][0]))
+	require.Equal(t, []string{moved.ID}, listIDs("laptop2"))
+
+	//a database made before the images were indexed gets its index when it is opened
+	require.NoError(t, db.Update(func(tx *bolt.Tx) error {
+		return tx.DeleteBucket(laptopImageBucket)
+	}))
+	require.NoError(t, db.Close())
+
+	db, err = OpenBoltDB(path)
+	require.NoError(t, err)
+	defer db.Close()
+
+	store = NewBoltImageStore(db, folder)
+	require.Equal(t, ids["laptop"][1:], listIDs("laptop"))
+	require.Equal(t, []string{moved.ID}, listIDs("laptop2"))
+}
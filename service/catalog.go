@@ -0,0 +1,260 @@
+package service
+
+import (
+	"demo-grpc/pb"
+	"fmt"
+	"path/filepath"
+
+	"github.com/google/uuid"
+)
+
+//maxImportFailures is the number of failed entries an import reports in detail, the others are only counted
+const maxImportFailures = 100
+
+//catalogEntry returns the laptop together with its rating and the info of its images
+func catalogEntry(laptop *pb.Laptop, ratingStore RatingStore, imageStore ImageStore) (*pb.CatalogEntry, error) {
+	entry := &pb.CatalogEntry{
+		Laptop: laptop,
+	}
+
+	if ratingStore != nil {
+		rating, err := ratingStore.Find(laptop.GetId())
+		if err != nil {
+			return nil, fmt.Errorf("Cannot find rating: %v", err)
+		}
+		if rating != nil {
+			entry.RatedCount = rating.Count
+			entry.RatingSum = rating.Sum
+		}
+	}
+
+	if imageStore != nil {
+		images, err := imageStore.List(laptop.GetId())
+		if err != nil {
+			return nil, fmt.Errorf("Cannot list images: %v", err)
+		}
+		for _, image := range images {
+			entry.Images = append(entry.Images, catalogImage(image))
+		}
+	}
+
+	return entry, nil
+}
+
+//catalogImage returns the catalog record of the info of an image
+func catalogImage(info *ImageInfo) *pb.CatalogImage {
+	image := &pb.CatalogImage{
+		Id:        info.ID,
+		ImageType: info.Type,
+		Path:      info.Path,
+		Size:      uint64(info.Size),
+		Width:     uint32(info.Width),
+		Height:    uint32(info.Height),
+	}
+	for _, variant := range info.Variants {
+		image.Variants = append(image.Variants, &pb.CatalogImageVariant{
+			MaxSide:   uint32(variant.MaxSide),
+			ImageType: variant.Type,
+			Path:      variant.Path,
+			Size:      uint64(variant.Size),
+			Width:     uint32(variant.Width),
+			Height:    uint32(variant.Height),
+		})
+	}
+	return image
+}
+
+//catalogImageInfo returns the info of an image of a laptop from its catalog record.
+//The record may come from a client, so its ID must be a UUID, its types must be ones the store accepts,
+//and its files must be named after them like the files the store writes
+func catalogImageInfo(laptopID string, image *pb.CatalogImage) (*ImageInfo, error) {
+	_, err := uuid.Parse(image.GetId())
+	if err != nil {
+		return nil, fmt.Errorf("Image ID is not a valid UUID: %v", err)
+	}
+
+	imageType, err := ParseImageType(image.GetImageType())
+	if err != nil {
+		return nil, err
+	}
+	err = checkCatalogImagePath(image.GetPath(), image.GetId()+imageType)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &ImageInfo{
+		ID:       image.GetId(),
+		LaptopID: laptopID,
+		Type:     imageType,
+		Path:     image.GetPath(),
+		Size:     int64(image.GetSize()),
+		Width:    int(image.GetWidth()),
+		Height:   int(image.GetHeight()),
+	}
+	for _, variant := range image.GetVariants() {
+		maxSide := int(variant.GetMaxSide())
+		if maxSide == 0 || !isImageVariantSize(maxSide) {
+			return nil, fmt.Errorf("Image %s has a variant of invalid size %d", info.ID, maxSide)
+		}
+
+		variantType, err := ParseImageType(variant.GetImageType())
+		if err != nil {
+			return nil, err
+		}
+		err = checkCatalogImagePath(variant.GetPath(), variantFileName(info.ID, maxSide)+variantType)
+		if err != nil {
+			return nil, err
+		}
+
+		info.Variants = withVariant(info.Variants, ImageVariant{
+			MaxSide: maxSide,
+			Type:    variantType,
+			Path:    variant.GetPath(),
+			Size:    int64(variant.GetSize()),
+			Width:   int(variant.GetWidth()),
+			Height:  int(variant.GetHeight()),
+		})
+	}
+	return info, nil
+}
+
+//checkCatalogImagePath returns an error if the file of a catalog image is not the file the store would write for it
+func checkCatalogImagePath(path string, name string) error {
+	if filepath.Base(path) != name {
+		return fmt.Errorf("Image file %q is not named %s", path, name)
+	}
+	return nil
+}
+
+//checkImageOwner returns an error if the image store already keeps the image for another laptop
+func checkImageOwner(imageStore ImageStore, info *ImageInfo) error {
+	existing, err := imageStore.Find(info.ID)
+	if err != nil {
+		return fmt.Errorf("Cannot find image: %v", err)
+	}
+	if existing != nil && existing.LaptopID != info.LaptopID {
+		return fmt.Errorf("Image %s belongs to another laptop", info.ID)
+	}
+	return nil
+}
+
+//catalogImporter saves catalog entries to the stores one at a time and keeps the summary of the import
+type catalogImporter struct {
+	mode        pb.ImportCatalogRequest_Mode
+	laptopStore LaptopStore
+	imageStore  ImageStore
+	ratingStore RatingStore
+	summary     *pb.ImportCatalogResponse
+
+	//checkNotTrashed, if set, is called with every laptop about to be created and stops it if it returns an error
+	checkNotTrashed func(laptopID string) error
+	//recordRevision, if set, is called with every laptop that is saved and its new version
+	recordRevision func(laptop *pb.Laptop, version uint64)
+}
+
+func newCatalogImporter(laptopStore LaptopStore, imageStore ImageStore, ratingStore RatingStore) *catalogImporter {
+	return &catalogImporter{
+		laptopStore: laptopStore,
+		imageStore:  imageStore,
+		ratingStore: ratingStore,
+		summary:     &pb.ImportCatalogResponse{},
+	}
+}
+
+//add imports an entry, an entry that cannot be imported is counted as failed and does not stop the import
+func (importer *catalogImporter) add(entry *pb.CatalogEntry) {
+	laptop := entry.GetLaptop()
+
+	err := importer.save(entry)
+	if err != nil {
+		importer.summary.Failed++
+		if len(importer.summary.Failures) < maxImportFailures {
+			importer.summary.Failures = append(importer.summary.Failures, &pb.ImportCatalogResponse_Failure{
+				LaptopId: laptop.GetId(),
+				Message:  err.Error(),
+			})
+		}
+	}
+}
+
+func (importer *catalogImporter) save(entry *pb.CatalogEntry) error {
+	laptop := entry.GetLaptop()
+	if laptop == nil {
+		return fmt.Errorf("Entry has no laptop")
+	}
+
+	_, err := uuid.Parse(laptop.GetId())
+	if err != nil {
+		return fmt.Errorf("Laptop ID is not a valid UUID: %v", err)
+	}
+
+	//the images are checked before anything is saved, so that an entry with a bad image is not imported at all
+	images := make([]*ImageInfo, 0, len(entry.GetImages()))
+	if importer.imageStore != nil {
+		for _, image := range entry.GetImages() {
+			info, err := catalogImageInfo(laptop.GetId(), image)
+			if err != nil {
+				return err
+			}
+			err = checkImageOwner(importer.imageStore, info)
+			if err != nil {
+				return err
+			}
+			images = append(images, info)
+		}
+	}
+
+	existing, err := importer.laptopStore.Find(laptop.GetId())
+	if err != nil {
+		return fmt.Errorf("Cannot find laptop: %v", err)
+	}
+
+	//an entry is only counted as created or updated once its rating and images are saved too
+	var count *uint32
+	var version uint64
+	switch {
+	case existing == nil:
+		if importer.checkNotTrashed != nil {
+			err = importer.checkNotTrashed(laptop.GetId())
+			if err != nil {
+				return err
+			}
+		}
+		err = importer.laptopStore.Save(laptop)
+		if err != nil {
+			return fmt.Errorf("Cannot save laptop: %v", err)
+		}
+		version = savedVersion(importer.laptopStore, laptop.GetId())
+		count = &importer.summary.Created
+	case importer.mode == pb.ImportCatalogRequest_UPSERT:
+		version, err = importer.laptopStore.Update(laptop, 0)
+		if err != nil {
+			return fmt.Errorf("Cannot update laptop: %v", err)
+		}
+		count = &importer.summary.Updated
+	default:
+		importer.summary.Skipped++
+		return nil
+	}
+
+	if importer.recordRevision != nil {
+		importer.recordRevision(laptop, version)
+	}
+
+	if importer.ratingStore != nil && entry.GetRatedCount() > 0 {
+		err = importer.ratingStore.Set(laptop.GetId(), &Rating{Count: entry.GetRatedCount(), Sum: entry.GetRatingSum()})
+		if err != nil {
+			return fmt.Errorf("Cannot save rating: %v", err)
+		}
+	}
+
+	for _, info := range images {
+		err = importer.imageStore.SaveInfo(info)
+		if err != nil {
+			return fmt.Errorf("Cannot save image info: %v", err)
+		}
+	}
+
+	*count++
+	return nil
+}
@@ -0,0 +1,370 @@
+package service
+
+import (
+	"bufio"
+	"demo-grpc/pb"
+	"demo-grpc/serializer"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	laptopLogFile      = "laptops.wal"
+	laptopSnapshotFile = "laptops.snapshot"
+	//defaultSnapshotEvery is the number of logged writes after which the log is compacted into a snapshot
+	defaultSnapshotEvery = 1000
+)
+
+//DiskLaptopStore stores laptops in memory and keeps them in a folder on disk, so they survive a restart.
+//Every write is appended to a write-ahead log before it is applied, and the log is compacted into a snapshot
+//of all laptops from time to time. Both hold LaptopEvent messages in the checksummed record format of serializer
+type DiskLaptopStore struct {
+	*InMemoryLaptopStore
+
+	//writeMutex orders the writes, so that the log and the memory store see them in the same order
+	writeMutex    sync.Mutex
+	folder        string
+	log           *os.File
+	logged        int
+	snapshotEvery int
+}
+
+//NewDiskLaptopStore opens the laptop store kept in folder, creating it if needed.
+//It compacts the log every snapshotEvery writes, or every 1000 writes if snapshotEvery is not positive
+func NewDiskLaptopStore(folder string, snapshotEvery int) (*DiskLaptopStore, error) {
+	if snapshotEvery <= 0 {
+		snapshotEvery = defaultSnapshotEvery
+	}
+
+	err := os.MkdirAll(folder, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot create laptop store folder: %v", err)
+	}
+
+	store := &DiskLaptopStore{
+		InMemoryLaptopStore: NewInMemoryLaptopStore(),
+		folder:              folder,
+		snapshotEvery:       snapshotEvery,
+	}
+
+	err = store.loadSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	err = store.replayLog()
+	if err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+//Save saves the laptop to store
+func (store *DiskLaptopStore) Save(laptop *pb.Laptop) error {
+	store.writeMutex.Lock()
+	defer store.writeMutex.Unlock()
+
+	if store.version(laptop.Id) != 0 {
+		return ErrAlreadyExists
+	}
+
+	err := store.append(&pb.LaptopEvent{Type: pb.LaptopEvent_CREATED, Laptop: laptop, Version: store.nextVersion(laptop.Id)})
+	if err != nil {
+		return err
+	}
+
+	err = store.InMemoryLaptopStore.Save(laptop)
+	if err != nil {
+		return err
+	}
+
+	store.compactIfNeeded()
+	return nil
+}
+
+//Update replaces the stored laptop that has the same ID and returns its new version.
+//If expectedVersion is not zero, it must match the stored version
+func (store *DiskLaptopStore) Update(laptop *pb.Laptop, expectedVersion uint64) (uint64, error) {
+	store.writeMutex.Lock()
+	defer store.writeMutex.Unlock()
+
+	version := store.version(laptop.Id)
+	if version == 0 {
+		return 0, ErrNotFound
+	}
+
+	if expectedVersion != 0 && version != expectedVersion {
+		return 0, ErrVersionMismatch
+	}
+
+	err := store.append(&pb.LaptopEvent{Type: pb.LaptopEvent_UPDATED, Laptop: laptop, Version: version + 1})
+	if err != nil {
+		return 0, err
+	}
+
+	version, err = store.InMemoryLaptopStore.Update(laptop, version)
+	if err != nil {
+		return 0, err
+	}
+
+	store.compactIfNeeded()
+	return version, nil
+}
+
+//Delete removes a laptop by ID. If expectedVersion is not zero, it must match the stored version
+func (store *DiskLaptopStore) Delete(id string, expectedVersion uint64) error {
+	store.writeMutex.Lock()
+	defer store.writeMutex.Unlock()
+
+	version := store.version(id)
+	if version == 0 {
+		return ErrNotFound
+	}
+
+	if expectedVersion != 0 && version != expectedVersion {
+		return ErrVersionMismatch
+	}
+
+	err := store.append(&pb.LaptopEvent{Type: pb.LaptopEvent_DELETED, Laptop: &pb.Laptop{Id: id}, Version: version})
+	if err != nil {
+		return err
+	}
+
+	err = store.InMemoryLaptopStore.Delete(id, version)
+	if err != nil {
+		return err
+	}
+
+	store.compactIfNeeded()
+	return nil
+}
+
+//Close closes the log, after compacting it into a snapshot
+func (store *DiskLaptopStore) Close() error {
+	store.writeMutex.Lock()
+	defer store.writeMutex.Unlock()
+
+	err := store.snapshot()
+	if err != nil {
+		return err
+	}
+
+	return store.log.Close()
+}
+
+//append writes an event to the log and waits until it is on disk
+func (store *DiskLaptopStore) append(event *pb.LaptopEvent) error {
+	record, err := serializer.MarshalChecksummedRecord(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = store.log.Write(record)
+	if err != nil {
+		return fmt.Errorf("Cannot write to laptop log: %v", err)
+	}
+
+	err = store.log.Sync()
+	if err != nil {
+		return fmt.Errorf("Cannot sync laptop log: %v", err)
+	}
+
+	store.logged++
+	return nil
+}
+
+//compactIfNeeded takes a snapshot once enough writes are logged. The writes are already safe in the log,
+//so a failed snapshot is only reported and tried again after the next write
+func (store *DiskLaptopStore) compactIfNeeded() {
+	if store.logged < store.snapshotEvery {
+		return
+	}
+
+	err := store.snapshot()
+	if err != nil {
+		log.Printf("Cannot take laptop snapshot: %v", err)
+	}
+}
+
+//snapshot writes all laptops to a new snapshot file, then empties the log.
+//The deleted laptops are kept as DELETED events, so that their IDs carry on from their last version after a restart.
+//It must be called with the write mutex held
+func (store *DiskLaptopStore) snapshot() error {
+	store.mutex.RLock()
+	events := store.existing(nil)
+	laptops := len(events)
+	events = append(events, store.retiredEvents()...)
+	store.mutex.RUnlock()
+
+	path := filepath.Join(store.folder, laptopSnapshotFile)
+	file, err := os.Create(path + ".tmp")
+	if err != nil {
+		return fmt.Errorf("Cannot create laptop snapshot: %v", err)
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	for _, event := range events {
+		err = serializer.WriteChecksummedRecord(writer, event)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = writer.Flush()
+	if err != nil {
+		return fmt.Errorf("Cannot write laptop snapshot: %v", err)
+	}
+
+	err = file.Sync()
+	if err != nil {
+		return fmt.Errorf("Cannot sync laptop snapshot: %v", err)
+	}
+
+	//the snapshot replaces the old one in a single step, and the log is only emptied after that.
+	//If the server stops in between, the log is replayed on top of the new snapshot, which changes nothing
+	//since every event holds the whole state it leaves its laptop in
+	err = os.Rename(path+".tmp", path)
+	if err != nil {
+		return fmt.Errorf("Cannot replace laptop snapshot: %v", err)
+	}
+
+	err = store.log.Truncate(0)
+	if err != nil {
+		return fmt.Errorf("Cannot truncate laptop log: %v", err)
+	}
+
+	_, err = store.log.Seek(0, io.SeekStart)
+	if err != nil {
+		return fmt.Errorf("Cannot truncate laptop log: %v", err)
+	}
+
+	log.Printf("Took a snapshot of %d laptops", laptops)
+	store.logged = 0
+	return nil
+}
+
+func (store *DiskLaptopStore) loadSnapshot() error {
+	file, err := os.Open(filepath.Join(store.folder, laptopSnapshotFile))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Cannot open laptop snapshot: %v", err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	for {
+		event := &pb.LaptopEvent{}
+		err := serializer.ReadChecksummedRecord(reader, event)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("Cannot read laptop snapshot: %v", err)
+		}
+
+		store.apply(event)
+	}
+}
+
+//replayLog applies the logged events and opens the log to append to it
+func (store *DiskLaptopStore) replayLog() error {
+	file, replayed, err := openLog(filepath.Join(store.folder, laptopLogFile), "laptop log",
+		func() proto.Message { return &pb.LaptopEvent{} },
+		func(record proto.Message) error {
+			store.apply(record.(*pb.LaptopEvent))
+			return nil
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Replayed %d records of the laptop log", replayed)
+	store.logged = replayed
+	store.log = file
+	return nil
+}
+
+//openLog reads the checksummed records of the log at path, creating it if needed, and opens it to append to it.
+//Each record is read into a message made by newRecord and passed to apply. It returns how many records it read.
+//A record cut short by a crash in the middle of a write can only be the last one, it is dropped from the log
+func openLog(
+	path string,
+	name string,
+	newRecord func() proto.Message,
+	apply func(record proto.Message) error,
+) (*os.File, int, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, 0, fmt.Errorf("Cannot open %s: %v", name, err)
+	}
+
+	counter := &countingReader{reader: file}
+	reader := bufio.NewReader(counter)
+	offset := int64(0)
+	read := 0
+
+	for {
+		record := newRecord()
+		err = serializer.ReadChecksummedRecord(reader, record)
+		if err == io.EOF {
+			break
+		}
+
+		if errors.Is(err, serializer.ErrChecksum) || err == io.ErrUnexpectedEOF {
+			_, peekErr := reader.Peek(1)
+			if peekErr != io.EOF {
+				file.Close()
+				return nil, 0, fmt.Errorf("The %s is corrupted at offset %d: %v", name, offset, err)
+			}
+
+			log.Printf("Dropping the incomplete last record of the %s at offset %d: %v", name, offset, err)
+			break
+		}
+
+		if err == nil {
+			err = apply(record)
+		}
+		if err != nil {
+			file.Close()
+			return nil, 0, fmt.Errorf("Cannot read %s: %v", name, err)
+		}
+
+		read++
+		offset = counter.count - int64(reader.Buffered())
+	}
+
+	err = file.Truncate(offset)
+	if err == nil {
+		_, err = file.Seek(offset, io.SeekStart)
+	}
+	if err != nil {
+		file.Close()
+		return nil, 0, fmt.Errorf("Cannot truncate %s: %v", name, err)
+	}
+
+	return file, read, nil
+}
+
+//countingReader counts the bytes read from a reader
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (counter *countingReader) Read(p []byte) (int, error) {
+	n, err := counter.reader.Read(p)
+	counter.count += int64(n)
+	return n, err
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"demo-grpc/pb"
+	"demo-grpc/sample"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskLaptopStore(t *testing.T) {
+	t.Parallel()
+
+	folder, err := ioutil.TempDir("", "laptops")
+	require.NoError(t, err)
+	defer os.RemoveAll(folder)
+
+	store, err := NewDiskLaptopStore(folder, 3)
+	require.NoError(t, err)
+
+	laptops := []*pb.Laptop{}
+	for i := 0; i < 5; i++ {
+		laptop := sample.NewLaptop()
+		require.NoError(t, store.Save(laptop))
+		laptops = append(laptops, laptop)
+	}
+
+	laptops[1].Name = "Updated"
+	version, err := store.Update(laptops[1], firstVersion)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), version)
+	require.NoError(t, store.Delete(laptops[2].Id, 0))
+
+	//a crash in the middle of a write leaves part of a record at the end of the log
+	require.NoError(t, store.log.Close())
+	record, err := ioutil.ReadFile(filepath.Join(folder, laptopLogFile))
+	require.NoError(t, err)
+	require.NotEmpty(t, record)
+
+	logFile, err := os.OpenFile(filepath.Join(folder, laptopLogFile), os.O_WRONLY|os.O_APPEND, 0644)
+	require.NoError(t, err)
+	_, err = logFile.Write(record[:len(record)/2])
+	require.NoError(t, err)
+	require.NoError(t, logFile.Close())
+
+	store, err = NewDiskLaptopStore(folder, 3)
+	require.NoError(t, err)
+
+	for i, laptop := range laptops {
+		found, version, err := store.FindWithVersion(laptop.Id)
+		require.NoError(t, err)
+
+		switch i {
+		case 1:
+			require.True(t, proto.Equal(laptop, found))
+			require.Equal(t, uint64(2), version)
+		case 2:
+			require.Nil(t, found)
+		default:
+			require.True(t, proto.Equal(laptop, found))
+			require.Equal(t, firstVersion, version)
+		}
+	}
+
+	other := sample.NewLaptop()
+	require.NoError(t, store.Save(other))
+	require.Equal(t, ErrAlreadyExists, store.Save(other))
+	require.NoError(t, store.Close())
+
+	store, err = NewDiskLaptopStore(folder, 3)
+	require.NoError(t, err)
+	defer store.Close()
+
+	found := 0
+	err = store.Search(context.Background(), nil, nil, func(laptop *pb.Laptop) error {
+		found++
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 5, found)
+
+	//the snapshot keeps the last version of the deleted laptop, which its ID carries on from
+	require.NoError(t, store.Save(laptops[2]))
+	_, version, err = store.FindWithVersion(laptops[2].Id)
+	require.NoError(t, err)
+	require.Equal(t, firstVersion+1, version)
+}
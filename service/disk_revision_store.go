@@ -0,0 +1,89 @@
+package service
+
+import (
+	"demo-grpc/pb"
+	"demo-grpc/serializer"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const revisionLogFile = "revisions.log"
+
+//DiskRevisionStore stores laptop revisions in memory and keeps them in a log on disk, so they survive a restart.
+//The history is append-only, so the log holds every revision ever recorded as a LaptopRevision message
+//in the checksummed record format of serializer, and it is never compacted
+type DiskRevisionStore struct {
+	*InMemoryRevisionStore
+
+	//writeMutex orders the appends, so that the log and the memory store number the revisions the same
+	writeMutex sync.Mutex
+	log        *os.File
+}
+
+//NewDiskRevisionStore opens the revision store kept in folder, creating it if needed.
+//The folder can be shared with a DiskLaptopStore
+func NewDiskRevisionStore(folder string) (*DiskRevisionStore, error) {
+	err := os.MkdirAll(folder, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot create revision store folder: %v", err)
+	}
+
+	store := &DiskRevisionStore{
+		InMemoryRevisionStore: NewInMemoryRevisionStore(),
+	}
+
+	file, _, err := openLog(filepath.Join(folder, revisionLogFile), "revision log",
+		func() proto.Message { return &pb.LaptopRevision{} },
+		func(record proto.Message) error {
+			return store.put(record.(*pb.LaptopRevision))
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	store.log = file
+	return store, nil
+}
+
+//Append writes a copy of the revision to the log, then stores it and returns its number
+func (store *DiskRevisionStore) Append(revision *pb.LaptopRevision) (uint64, error) {
+	store.writeMutex.Lock()
+	defer store.writeMutex.Unlock()
+
+	other := proto.Clone(revision).(*pb.LaptopRevision)
+	other.Revision = store.count(other.GetLaptopId()) + 1
+
+	record, err := serializer.MarshalChecksummedRecord(other)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = store.log.Write(record)
+	if err != nil {
+		return 0, fmt.Errorf("Cannot write to revision log: %v", err)
+	}
+
+	err = store.log.Sync()
+	if err != nil {
+		return 0, fmt.Errorf("Cannot sync revision log: %v", err)
+	}
+
+	err = store.put(other)
+	if err != nil {
+		return 0, err
+	}
+	return other.Revision, nil
+}
+
+//Close closes the log
+func (store *DiskRevisionStore) Close() error {
+	store.writeMutex.Lock()
+	defer store.writeMutex.Unlock()
+
+	return store.log.Close()
+}
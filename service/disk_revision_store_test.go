@@ -0,0 +1,66 @@
+package service
+
+import (
+	"demo-grpc/pb"
+	"demo-grpc/sample"
+	"demo-grpc/serializer"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskRevisionStore(t *testing.T) {
+	t.Parallel()
+
+	folder, err := ioutil.TempDir("", "revisions")
+	require.NoError(t, err)
+	defer os.RemoveAll(folder)
+
+	store, err := NewDiskRevisionStore(folder)
+	require.NoError(t, err)
+
+	laptop := sample.NewLaptop()
+	for i := 0; i < 3; i++ {
+		_, err = store.Append(&pb.LaptopRevision{LaptopId: laptop.Id, Laptop: laptop, Version: uint64(i + 1)})
+		require.NoError(t, err)
+	}
+	_, err = store.Append(&pb.LaptopRevision{LaptopId: "other"})
+	require.NoError(t, err)
+
+	//a crash in the middle of an append leaves part of a record at the end of the log
+	require.NoError(t, store.Close())
+	record, err := serializer.MarshalChecksummedRecord(&pb.LaptopRevision{LaptopId: laptop.Id, Revision: 4, Laptop: laptop})
+	require.NoError(t, err)
+
+	logFile, err := os.OpenFile(filepath.Join(folder, revisionLogFile), os.O_WRONLY|os.O_APPEND, 0644)
+	require.NoError(t, err)
+	_, err = logFile.Write(record[:len(record)/2])
+	require.NoError(t, err)
+	require.NoError(t, logFile.Close())
+
+	//the history survives a restart and goes on from where it was
+	store, err = NewDiskRevisionStore(folder)
+	require.NoError(t, err)
+	defer store.Close()
+
+	revisions, err := store.List(laptop.Id, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, revisions, 3)
+	for i, revision := range revisions {
+		require.EqualValues(t, i+1, revision.GetRevision())
+		require.EqualValues(t, i+1, revision.GetVersion())
+		require.True(t, proto.Equal(laptop, revision.GetLaptop()))
+	}
+
+	number, err := store.Append(&pb.LaptopRevision{LaptopId: laptop.Id, Operation: pb.LaptopRevision_DELETE})
+	require.NoError(t, err)
+	require.EqualValues(t, 4, number)
+
+	found, err := store.Find("other", 1)
+	require.NoError(t, err)
+	require.NotNil(t, found)
+}
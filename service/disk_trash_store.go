@@ -0,0 +1,169 @@
+package service
+
+import (
+	"demo-grpc/pb"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+)
+
+const trashFileExt = ".trashed"
+
+//DiskTrashStore keeps each soft-deleted laptop in a folder on disk as <id>.trashed,
+//which holds the TrashedLaptop in protobuf binary, so that the trash survives a restart
+type DiskTrashStore struct {
+	mutex  sync.RWMutex
+	folder string
+}
+
+//NewDiskTrashStore returns a new DiskTrashStore, creating its folder if needed
+func NewDiskTrashStore(folder string) (*DiskTrashStore, error) {
+	err := os.MkdirAll(folder, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot create trash folder: %v", err)
+	}
+
+	return &DiskTrashStore{
+		folder: folder,
+	}, nil
+}
+
+//Put moves a copy of the laptop to the trash, there can only be one laptop with the same ID
+func (store *DiskTrashStore) Put(laptop *pb.TrashedLaptop) error {
+	id := laptop.GetLaptop().GetId()
+	//only UUIDs can name a file of the store
+	if _, err := uuid.Parse(id); err != nil {
+		return fmt.Errorf("Laptop ID is not a valid UUID: %v", err)
+	}
+
+	data, err := proto.Marshal(laptop)
+	if err != nil {
+		return fmt.Errorf("Cannot marshal trashed laptop: %v", err)
+	}
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	path := store.path(id)
+	_, err = os.Stat(path)
+	if err == nil {
+		return ErrAlreadyExists
+	}
+	if !os.IsNotExist(err) {
+		return fmt.Errorf("Cannot read trash file: %v", err)
+	}
+
+	//the file appears in a single step once its data is on disk, so that a crash never leaves half a laptop
+	file, err := os.Create(path + ".tmp")
+	if err != nil {
+		return fmt.Errorf("Cannot create trash file: %v", err)
+	}
+
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+	file.Close()
+	if err == nil {
+		err = os.Rename(path+".tmp", path)
+	}
+	if err != nil {
+		os.Remove(path + ".tmp")
+		return fmt.Errorf("Cannot write trash file: %v", err)
+	}
+
+	return nil
+}
+
+//Find returns a laptop of the trash by ID, or nil if it is not in the trash
+func (store *DiskTrashStore) Find(id string) (*pb.TrashedLaptop, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, nil
+	}
+
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	return store.read(id)
+}
+
+//Remove takes a laptop out of the trash by ID
+func (store *DiskTrashStore) Remove(id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return ErrNotFound
+	}
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	err := os.Remove(store.path(id))
+	if os.IsNotExist(err) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("Cannot remove trash file: %v", err)
+	}
+	return nil
+}
+
+//List returns up to limit laptops of the trash in ascending ID order, starting right after afterID
+func (store *DiskTrashStore) List(afterID string, limit int) ([]*pb.TrashedLaptop, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	//the files are sorted by name, which sorts them by ID since they all have the same extension
+	files, err := ioutil.ReadDir(store.folder)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot read trash folder: %v", err)
+	}
+
+	laptops := []*pb.TrashedLaptop{}
+	for _, file := range files {
+		if len(laptops) == limit {
+			break
+		}
+
+		id := strings.TrimSuffix(file.Name(), trashFileExt)
+		if id == file.Name() || id <= afterID {
+			continue
+		}
+
+		laptop, err := store.read(id)
+		if err != nil {
+			return nil, err
+		}
+		if laptop != nil {
+			laptops = append(laptops, laptop)
+		}
+	}
+	return laptops, nil
+}
+
+//read returns a laptop of the trash by ID, or nil if it is not in the trash.
+//It must be called with the mutex held
+func (store *DiskTrashStore) read(id string) (*pb.TrashedLaptop, error) {
+	data, err := ioutil.ReadFile(store.path(id))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Cannot read trash file: %v", err)
+	}
+
+	laptop := &pb.TrashedLaptop{}
+	err = proto.Unmarshal(data, laptop)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot unmarshal trashed laptop: %v", err)
+	}
+	return laptop, nil
+}
+
+func (store *DiskTrashStore) path(id string) string {
+	return filepath.Join(store.folder, id+trashFileExt)
+}
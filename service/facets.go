@@ -0,0 +1,114 @@
+package service
+
+import (
+	"demo-grpc/pb"
+	"math"
+	"sort"
+)
+
+const defaultPriceBucketUsd = 500
+
+//ramBucket groups laptops whose RAM is at least minGB and less than the minGB of the next bucket
+type ramBucket struct {
+	label string
+	minGB uint64
+}
+
+var ramBuckets = []ramBucket{
+	{"<8GB", 0},
+	{"8-16GB", 8},
+	{"16-32GB", 16},
+	{"32-64GB", 32},
+	{">=64GB", 64},
+}
+
+//facetCounter aggregates the facets of a search result one laptop at a time
+type facetCounter struct {
+	priceBucketUsd float64
+	total          uint32
+	brands         map[string]uint32
+	cpuBrands      map[string]uint32
+	ram            []uint32
+	screenPanels   map[string]uint32
+	prices         map[int64]uint32
+}
+
+func newFacetCounter(priceBucketUsd float64) *facetCounter {
+	if priceBucketUsd <= 0 {
+		priceBucketUsd = defaultPriceBucketUsd
+	}
+
+	return &facetCounter{
+		priceBucketUsd: priceBucketUsd,
+		brands:         make(map[string]uint32),
+		cpuBrands:      make(map[string]uint32),
+		ram:            make([]uint32, len(ramBuckets)),
+		screenPanels:   make(map[string]uint32),
+		prices:         make(map[int64]uint32),
+	}
+}
+
+func (counter *facetCounter) add(laptop *pb.Laptop) {
+	counter.total++
+	counter.brands[laptop.GetBrand()]++
+	counter.cpuBrands[laptop.GetCpu().GetBrand()]++
+	counter.screenPanels[laptop.GetScreen().GetPanel().String()]++
+
+	ram := toBit(laptop.GetRam())
+	for i := len(ramBuckets) - 1; i >= 0; i-- {
+		if ram >= toBit(&pb.Memory{Value: ramBuckets[i].minGB, Unit: pb.Memory_GIGABYTE}) {
+			counter.ram[i]++
+			break
+		}
+	}
+
+	bucket := int64(math.Floor(laptop.GetPriceUsd() / counter.priceBucketUsd))
+	counter.prices[bucket]++
+}
+
+func (counter *facetCounter) response() *pb.SearchFacetsResponse {
+	res := &pb.SearchFacetsResponse{
+		Total:        counter.total,
+		Brands:       sortedFacetCounts(counter.brands),
+		CpuBrands:    sortedFacetCounts(counter.cpuBrands),
+		ScreenPanels: sortedFacetCounts(counter.screenPanels),
+	}
+
+	for i, bucket := range ramBuckets {
+		if counter.ram[i] > 0 {
+			res.Ram = append(res.Ram, &pb.FacetCount{Value: bucket.label, Count: counter.ram[i]})
+		}
+	}
+
+	buckets := make([]int64, 0, len(counter.prices))
+	for bucket := range counter.prices {
+		buckets = append(buckets, bucket)
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i] < buckets[j] })
+
+	for _, bucket := range buckets {
+		res.Prices = append(res.Prices, &pb.PriceBucket{
+			MinPriceUsd: float64(bucket) * counter.priceBucketUsd,
+			MaxPriceUsd: float64(bucket+1) * counter.priceBucketUsd,
+			Count:       counter.prices[bucket],
+		})
+	}
+
+	return res
+}
+
+//sortedFacetCounts returns the counts with the most common value first
+func sortedFacetCounts(counts map[string]uint32) []*pb.FacetCount {
+	facets := make([]*pb.FacetCount, 0, len(counts))
+	for value, count := range counts {
+		facets = append(facets, &pb.FacetCount{Value: value, Count: count})
+	}
+
+	sort.Slice(facets, func(i, j int) bool {
+		if facets[i].Count != facets[j].Count {
+			return facets[i].Count > facets[j].Count
+		}
+		return facets[i].Value < facets[j].Value
+	})
+	return facets
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+//validateFieldMask checks that every path of the mask names an existing field of the message
+func validateFieldMask(message proto.Message, paths []string) error {
+	for _, path := range paths {
+		if err := validatePath(message.ProtoReflect().Descriptor(), strings.Split(path, ".")); err != nil {
+			return fmt.Errorf("Invalid field mask path %q: %v", path, err)
+		}
+	}
+
+	return nil
+}
+
+func validatePath(desc protoreflect.MessageDescriptor, names []string) error {
+	name := protoreflect.Name(names[0])
+
+	if len(names) == 1 && desc.Oneofs().ByName(name) != nil {
+		return nil
+	}
+
+	field := desc.Fields().ByName(name)
+	if field == nil {
+		return fmt.Errorf("%s has no field %s", desc.FullName(), name)
+	}
+
+	if len(names) == 1 {
+		return nil
+	}
+
+	if field.Kind() != protoreflect.MessageKind || field.IsList() || field.IsMap() {
+		return fmt.Errorf("field %s of %s has no sub-fields", name, desc.FullName())
+	}
+
+	return validatePath(field.Message(), names[1:])
+}
+
+//applyFieldMask copies the fields named by paths from src into dst, clearing those that are unset in src
+func applyFieldMask(dst proto.Message, src proto.Message, paths []string) error {
+	err := validateFieldMask(dst, paths)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		applyPath(dst.ProtoReflect(), src.ProtoReflect(), strings.Split(path, "."))
+	}
+
+	return nil
+}
+
+func applyPath(dst protoreflect.Message, src protoreflect.Message, names []string) {
+	name := protoreflect.Name(names[0])
+
+	if len(names) == 1 {
+		if oneof := dst.Descriptor().Oneofs().ByName(name); oneof != nil {
+			if field := dst.WhichOneof(oneof); field != nil {
+				dst.Clear(field)
+			}
+			if field := src.WhichOneof(oneof); field != nil {
+				dst.Set(field, cloneValue(field, src.Get(field)))
+			}
+			return
+		}
+	}
+
+	field := dst.Descriptor().Fields().ByName(name)
+
+	if len(names) == 1 {
+		if src.Has(field) {
+			dst.Set(field, cloneValue(field, src.Get(field)))
+		} else {
+			dst.Clear(field)
+		}
+		return
+	}
+
+	applyPath(dst.Mutable(field).Message(), src.Get(field).Message(), names[1:])
+}
+
+func cloneValue(field protoreflect.FieldDescriptor, value protoreflect.Value) protoreflect.Value {
+	if field.Kind() != protoreflect.MessageKind || field.IsList() || field.IsMap() {
+		return value
+	}
+
+	return protoreflect.ValueOfMessage(proto.Clone(value.Message().Interface()).ProtoReflect())
+}
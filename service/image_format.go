@@ -0,0 +1,113 @@
+package service
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"strings"
+)
+
+//ErrUnsupportedImageType is returned for an image type that is not one of the types the store accepts
+var ErrUnsupportedImageType = errors.New("Image type is not supported")
+
+//ErrImageFormat is returned when the data of an image is not an image of its declared type
+var ErrImageFormat = errors.New("Image data does not match its type")
+
+//imageTypes maps the image types a client may declare to the type the store keeps, which is also the file extension
+var imageTypes = map[string]string{
+	".jpg":  ".jpg",
+	".jpeg": ".jpg",
+	".png":  ".png",
+	".gif":  ".gif",
+	".webp": ".webp",
+}
+
+//sniffLength is the number of bytes sniffImageType needs
+const sniffLength = 12
+
+//ParseImageType returns the type the store keeps for a declared image type, which is a file extension like ".jpg" or ".JPEG"
+func ParseImageType(imageType string) (string, error) {
+	canonical, ok := imageTypes[strings.ToLower(imageType)]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedImageType, imageType)
+	}
+	return canonical, nil
+}
+
+//sniffImageType returns the type of an image from its first bytes, or an empty string if it is of no supported type
+func sniffImageType(header []byte) string {
+	switch {
+	case bytes.HasPrefix(header, []byte("\xff\xd8\xff")):
+		return ".jpg"
+	case bytes.HasPrefix(header, []byte("\x89PNG\r\n\x1a\n")):
+		return ".png"
+	case bytes.HasPrefix(header, []byte("GIF87a")), bytes.HasPrefix(header, []byte("GIF89a")):
+		return ".gif"
+	case len(header) >= 12 && string(header[:4]) == "RIFF" && string(header[8:12]) == "WEBP":
+		return ".webp"
+	default:
+		return ""
+	}
+}
+
+//imageDimensions decodes the header of an image of a given type and returns its width and height in pixels
+func imageDimensions(data io.Reader, imageType string) (int, int, error) {
+	var config image.Config
+	var err error
+
+	switch imageType {
+	case ".jpg":
+		config, err = jpeg.DecodeConfig(data)
+	case ".png":
+		config, err = png.DecodeConfig(data)
+	case ".gif":
+		config, err = gif.DecodeConfig(data)
+	case ".webp":
+		return webpDimensions(data)
+	default:
+		return 0, 0, ErrUnsupportedImageType
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return config.Width, config.Height, nil
+}
+
+//webpDimensions reads the dimensions from the header of the first chunk of a WebP image,
+//which is VP8 for a lossy image, VP8L for a lossless one, and VP8X for an extended one
+func webpDimensions(data io.Reader) (int, int, error) {
+	header := make([]byte, 30)
+	_, err := io.ReadFull(data, header)
+	if err != nil {
+		return 0, 0, fmt.Errorf("Cannot read WebP header: %v", err)
+	}
+
+	switch string(header[12:16]) {
+	case "VP8 ":
+		if !bytes.Equal(header[23:26], []byte{0x9d, 0x01, 0x2a}) {
+			return 0, 0, errors.New("Invalid VP8 start code")
+		}
+		width := binary.LittleEndian.Uint16(header[26:28]) & 0x3fff
+		height := binary.LittleEndian.Uint16(header[28:30]) & 0x3fff
+		return int(width), int(height), nil
+	case "VP8L":
+		if header[20] != 0x2f {
+			return 0, 0, errors.New("Invalid VP8L signature")
+		}
+		bits := binary.LittleEndian.Uint32(header[21:25])
+		return int(bits&0x3fff) + 1, int(bits>>14&0x3fff) + 1, nil
+	case "VP8X":
+		width := uint32(header[24]) | uint32(header[25])<<8 | uint32(header[26])<<16
+		height := uint32(header[27]) | uint32(header[28])<<8 | uint32(header[29])<<16
+		return int(width) + 1, int(height) + 1, nil
+	default:
+		return 0, 0, fmt.Errorf("Unknown WebP chunk %q", header[12:16])
+	}
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"bytes"
+	"demo-grpc/sample"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSniffImageType(t *testing.T) {
+	t.Parallel()
+
+	for _, imageType := range []string{".jpg", ".png", ".gif"} {
+		require.Equal(t, imageType, sniffImageType(sample.NewImage(imageType, 2, 2)[:sniffLength]))
+	}
+	require.Equal(t, ".webp", sniffImageType([]byte("RIFF\x00\x00\x00\x00WEBP")))
+	require.Equal(t, "", sniffImageType([]byte("RIFF\x00\x00\x00\x00WAVE")))
+	require.Equal(t, "", sniffImageType([]byte("MZ")))
+}
+
+func TestWebpDimensions(t *testing.T) {
+	t.Parallel()
+
+	file, err := os.Open("../tmp/laptop.webp")
+	require.NoError(t, err)
+	defer file.Close()
+
+	width, height, err := imageDimensions(file, ".webp")
+	require.NoError(t, err)
+	require.Equal(t, 1200, width)
+	require.Equal(t, 628, height)
+
+	header := func(chunk string, data ...byte) []byte {
+		header := append([]byte("RIFF\x00\x00\x00\x00WEBP"+chunk+"\x00\x00\x00\x00"), data...)
+		return append(header, make([]byte, 30-len(header))...)
+	}
+
+	//lossy: a frame tag, the start code, then 14 bits of width and of height
+	width, height, err = webpDimensions(bytes.NewReader(header("VP8 ", 0, 0, 0, 0x9d, 0x01, 0x2a, 0x40, 0x01, 0xf0, 0x00)))
+	require.NoError(t, err)
+	require.Equal(t, 320, width)
+	require.Equal(t, 240, height)
+
+	//lossless: the signature, then 14 bits of width minus one and of height minus one
+	bits := uint32(639) | uint32(479)<<14
+	width, height, err = webpDimensions(bytes.NewReader(header("VP8L", 0x2f, byte(bits), byte(bits>>8), byte(bits>>16), byte(bits>>24))))
+	require.NoError(t, err)
+	require.Equal(t, 640, width)
+	require.Equal(t, 480, height)
+
+	_, _, err = webpDimensions(bytes.NewReader(header("VP8 ", 0, 0, 0, 1, 2, 3)))
+	require.Error(t, err)
+	_, _, err = webpDimensions(bytes.NewReader([]byte("RIFF")))
+	require.Error(t, err)
+}
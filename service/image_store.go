@@ -1,17 +1,41 @@
 package service
 
 import (
-	"bytes"
+	"bufio"
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
 	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/google/uuid"
 )
 
-//ImageStore is an interface to store laptop images
+//ErrImageTooLarge is returned when the data of an image is larger than its size limit
+var ErrImageTooLarge = errors.New("Image is too large")
+
+//ImageStore is an interface to store laptop images.
+//A new image is first staged, which can take as long as its data takes to arrive, then saved, which is quick
 type ImageStore interface {
-	Save(laptopID string, imageType string, imageData bytes.Buffer) (string, error)
+	//Stage reads a new image from imageData until io.EOF, failing with ErrImageTooLarge
+	//as soon as there are more than maxSize bytes. The image is not in the store until it is saved
+	Stage(imageType string, imageData io.Reader, maxSize int64) (*StagedImage, error)
+	//Save adds a staged image to the store as an image of a laptop and returns its ID
+	Save(laptopID string, staged *StagedImage) (string, error)
+	SaveInfo(info *ImageInfo) error
+	//SaveVariant adds a staged image to the store as a variant of an image, replacing the variant with the same maxSide if there is one
+	SaveVariant(imageID string, maxSide int, staged *StagedImage) error
+	Find(imageID string) (*ImageInfo, error)
+	Open(imageID string) (io.ReadCloser, error)
+	OpenVariant(imageID string, maxSide int) (io.ReadCloser, error)
+	List(laptopID string) ([]*ImageInfo, error)
+	Delete(imageID string) error
 }
 
 //DiskImageStore stores images on disk and its info on memory
@@ -23,9 +47,40 @@ type DiskImageStore struct {
 
 //ImageInfo contains information about the image
 type ImageInfo struct {
+	ID       string
 	LaptopID string
 	Type     string
 	Path     string
+	Size     int64
+	Width    int
+	Height   int
+	Variants []ImageVariant `json:",omitempty"`
+}
+
+//StagedImage is a new image whose data is written and checked in a temp file of the folder of a store,
+//the file is moved into place when the image is saved
+type StagedImage struct {
+	tmpPath string
+	info    ImageInfo
+}
+
+//Discard removes the temp file of an image that is not saved, it does nothing once the image is saved
+func (staged *StagedImage) Discard() {
+	if staged.tmpPath != "" {
+		os.Remove(staged.tmpPath)
+		staged.tmpPath = ""
+	}
+}
+
+//SaveImage stages a new image read from imageData and saves it as an image of a laptop, it returns the ID of the image
+func SaveImage(store ImageStore, laptopID string, imageType string, imageData io.Reader, maxSize int64) (string, error) {
+	staged, err := store.Stage(imageType, imageData, maxSize)
+	if err != nil {
+		return "", err
+	}
+	defer staged.Discard()
+
+	return store.Save(laptopID, staged)
 }
 
 //NewDiskImageStore returns a new DiskImageStore
@@ -36,36 +91,431 @@ func NewDiskImageStore(imageFolder string) *DiskImageStore {
 	}
 }
 
+//Stage writes a new image to a temp file of the image folder
+func (store *DiskImageStore) Stage(imageType string, imageData io.Reader, maxSize int64) (*StagedImage, error) {
+	return stageImageFile(store.imageFolder, imageType, imageData, maxSize)
+}
+
 //Save saves a new laptop image to the store
-func (store *DiskImageStore) Save(
-	laptopID string,
-	imageType string,
-	imageData bytes.Buffer,
-) (string, error) {
+func (store *DiskImageStore) Save(laptopID string, staged *StagedImage) (string, error) {
 	imageID, err := uuid.NewRandom()
 	if err != nil {
 		return "", fmt.Errorf("Cannot generate image id: %v", err)
 	}
 
-	imagePath := fmt.Sprintf("%s/%s%s", store.imageFolder, imageID, imageType)
-
-	file, err := os.Create(imagePath)
+	info, err := placeStagedImage(store.imageFolder, imageID.String(), staged)
 	if err != nil {
 		return "", err
 	}
+	info.ID = imageID.String()
+	info.LaptopID = laptopID
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.images[info.ID] = info
+	return imageID.String(), nil
+}
 
-	_, err = imageData.WriteTo(file)
+//SaveInfo records the info of an image whose file is already in place, replacing any info with the same ID.
+//The file is the one the store would write for the ID and type of the image, whatever the path of the info
+func (store *DiskImageStore) SaveInfo(info *ImageInfo) error {
+	placed, err := placeImageInfo(store.imageFolder, info)
 	if err != nil {
-		return "", fmt.Errorf("Cannot write image to the file: %v", err)
+		return err
 	}
 
 	store.mutex.Lock()
 	defer store.mutex.Unlock()
 
-	store.images[imageID.String()] = &ImageInfo{
-		LaptopID: laptopID,
-		Type:     imageType,
-		Path:     imagePath,
+	store.images[info.ID] = placed
+	return nil
+}
+
+//SaveVariant saves a variant of an image next to the image
+func (store *DiskImageStore) SaveVariant(imageID string, maxSide int, staged *StagedImage) error {
+	file, err := placeStagedImage(store.imageFolder, variantFileName(imageID, maxSide), staged)
+	if err != nil {
+		return err
 	}
-	return imageID.String(), nil
+	variant := newImageVariant(file, maxSide)
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	info := store.images[imageID]
+	if info == nil {
+		removeImageFile(variant.Path)
+		return ErrNotFound
+	}
+
+	//a replaced variant of another type is in another file
+	if old := info.Variant(maxSide); old != nil && old.Path != variant.Path {
+		removeImageFile(old.Path)
+	}
+
+	other := copyImageInfo(info)
+	other.Variants = withVariant(info.Variants, variant)
+	store.images[imageID] = other
+	return nil
+}
+
+//Find returns the info of an image by ID, or nil if there is no such image
+func (store *DiskImageStore) Find(imageID string) (*ImageInfo, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	info := store.images[imageID]
+	if info == nil {
+		return nil, nil
+	}
+
+	return copyImageInfo(info), nil
+}
+
+//Open opens the file of an image by ID for reading, the caller must close it
+func (store *DiskImageStore) Open(imageID string) (io.ReadCloser, error) {
+	info, err := store.Find(imageID)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, ErrNotFound
+	}
+
+	return openImageFile(info.Path)
+}
+
+//OpenVariant opens the file of a variant of an image for reading, the caller must close it
+func (store *DiskImageStore) OpenVariant(imageID string, maxSide int) (io.ReadCloser, error) {
+	info, err := store.Find(imageID)
+	if err != nil {
+		return nil, err
+	}
+
+	return openVariantFile(info, maxSide)
+}
+
+//List returns the info of the images of a laptop, ordered by image ID
+func (store *DiskImageStore) List(laptopID string) ([]*ImageInfo, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	images := []*ImageInfo{}
+	for _, info := range store.images {
+		if info.LaptopID == laptopID {
+			images = append(images, copyImageInfo(info))
+		}
+	}
+
+	sortImages(images)
+	return images, nil
+}
+
+func sortImages(images []*ImageInfo) {
+	sort.Slice(images, func(i, j int) bool {
+		return images[i].ID < images[j].ID
+	})
+}
+
+//Delete removes an image by ID, together with its file and the files of its variants
+func (store *DiskImageStore) Delete(imageID string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	info := store.images[imageID]
+	if info == nil {
+		return ErrNotFound
+	}
+
+	err := removeVariantFiles(info)
+	if err != nil {
+		return err
+	}
+	err = removeImageFile(info.Path)
+	if err != nil {
+		return err
+	}
+
+	delete(store.images, imageID)
+	return nil
+}
+
+//stageImageFile streams the data of an image to a temp file in imageFolder, which placeStagedImage renames once
+//all the data is written and checked, so that an image file never holds a partial or an invalid image.
+//The data must be of the declared image type
+func stageImageFile(imageFolder string, imageType string, imageData io.Reader, maxSize int64) (*StagedImage, error) {
+	imageType, err := ParseImageType(imageType)
+	if err != nil {
+		return nil, err
+	}
+
+	//the type is checked on the first bytes, before the rest of the data is received
+	reader := bufio.NewReader(imageData)
+	header, err := reader.Peek(sniffLength)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("Cannot read image data: %w", err)
+	}
+	if sniffImageType(header) != imageType {
+		return nil, fmt.Errorf("%w: data is not %s", ErrImageFormat, imageType)
+	}
+
+	file, err := ioutil.TempFile(imageFolder, ".image-*.tmp")
+	if err != nil {
+		return nil, fmt.Errorf("Cannot create image file: %v", err)
+	}
+	staged := &StagedImage{tmpPath: file.Name()}
+	defer func() {
+		file.Close()
+		if staged.info.Type == "" {
+			staged.Discard()
+		}
+	}()
+
+	size, err := io.Copy(file, io.LimitReader(reader, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("Cannot write image to the file: %w", err)
+	}
+	if size > maxSize {
+		return nil, ErrImageTooLarge
+	}
+
+	_, err = file.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot read image file: %v", err)
+	}
+	width, height, err := imageDimensions(bufio.NewReader(file), imageType)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrImageFormat, err)
+	}
+
+	err = file.Sync()
+	if err != nil {
+		return nil, fmt.Errorf("Cannot write image to the file: %v", err)
+	}
+
+	//a staged image has a type, an image that failed does not and its file is removed
+	staged.info = ImageInfo{
+		Type:   imageType,
+		Size:   size,
+		Width:  width,
+		Height: height,
+	}
+	return staged, nil
+}
+
+//placeStagedImage renames the temp file of a staged image to the file of an image or a variant named name,
+//so that nothing the client sends ends up in the path. It returns the info of the image without its IDs
+func placeStagedImage(imageFolder string, name string, staged *StagedImage) (*ImageInfo, error) {
+	if staged.tmpPath == "" {
+		return nil, errors.New("Staged image is already saved or discarded")
+	}
+
+	info := staged.info
+	info.Path = imageFilePath(imageFolder, name, info.Type)
+
+	err := os.Rename(staged.tmpPath, info.Path)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot move image file into place: %v", err)
+	}
+	staged.tmpPath = ""
+
+	return &info, nil
+}
+
+//imageFilePath returns the path of the file of an image or a variant named name, which is an image ID or a variantFileName
+func imageFilePath(imageFolder string, name string, imageType string) string {
+	return filepath.Join(imageFolder, name+imageType)
+}
+
+//variantFileName returns the name of the file of a variant of an image, without its type
+func variantFileName(imageID string, maxSide int) string {
+	return fmt.Sprintf("%s_%d", imageID, maxSide)
+}
+
+//placeImageInfo returns a copy of the info of an image whose paths are those of the files the store keeps in imageFolder
+//for the ID and types of the image. The paths of the given info are ignored, so that no caller can point the store at another file
+func placeImageInfo(imageFolder string, info *ImageInfo) (*ImageInfo, error) {
+	_, err := uuid.Parse(info.ID)
+	if err != nil {
+		return nil, fmt.Errorf("Image ID is not a valid UUID: %v", err)
+	}
+
+	placed := copyImageInfo(info)
+	placed.Type, err = ParseImageType(info.Type)
+	if err != nil {
+		return nil, err
+	}
+	placed.Path = imageFilePath(imageFolder, info.ID, placed.Type)
+
+	for i := range placed.Variants {
+		variant := &placed.Variants[i]
+		variant.Type, err = ParseImageType(variant.Type)
+		if err != nil {
+			return nil, err
+		}
+		variant.Path = imageFilePath(imageFolder, variantFileName(info.ID, variant.MaxSide), variant.Type)
+	}
+
+	return placed, nil
+}
+
+//newImageVariant returns the info of a variant from the info returned by placeStagedImage
+func newImageVariant(info *ImageInfo, maxSide int) ImageVariant {
+	return ImageVariant{
+		MaxSide: maxSide,
+		Type:    info.Type,
+		Path:    info.Path,
+		Size:    info.Size,
+		Width:   info.Width,
+		Height:  info.Height,
+	}
+}
+
+//Variant returns the variant of the image with the given longest side, or nil if there is none
+func (info *ImageInfo) Variant(maxSide int) *ImageVariant {
+	for i := range info.Variants {
+		if info.Variants[i].MaxSide == maxSide {
+			return &info.Variants[i]
+		}
+	}
+	return nil
+}
+
+func copyImageInfo(info *ImageInfo) *ImageInfo {
+	other := *info
+	if info.Variants != nil {
+		other.Variants = append([]ImageVariant(nil), info.Variants...)
+	}
+	return &other
+}
+
+//openVariantFile opens the file of a variant of an image, a missing image or variant is ErrNotFound
+func openVariantFile(info *ImageInfo, maxSide int) (io.ReadCloser, error) {
+	if info == nil {
+		return nil, ErrNotFound
+	}
+
+	variant := info.Variant(maxSide)
+	if variant == nil {
+		return nil, ErrNotFound
+	}
+	return openImageFile(variant.Path)
+}
+
+//removeImageFile removes the file of an image, a file that is already gone is not an error
+func removeImageFile(path string) error {
+	err := os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("Cannot remove image file: %v", err)
+	}
+	return nil
+}
+
+//openImageFile opens the file of an image, a file that is gone is ErrNotFound
+func openImageFile(path string) (io.ReadCloser, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Cannot open image file: %v", err)
+	}
+	return file, nil
+}
+
+//ImageSizeLimits are the largest sizes of the images in bytes, by image type
+type ImageSizeLimits struct {
+	//Default is the limit of the image types that have none of their own
+	Default int64
+	Types   map[string]int64
+}
+
+//DefaultImageSizeLimits returns the limits that allow images of up to 1 MiB of any type
+func DefaultImageSizeLimits() ImageSizeLimits {
+	return ImageSizeLimits{Default: 1 << 20}
+}
+
+//For returns the size limit of an image type
+func (limits ImageSizeLimits) For(imageType string) int64 {
+	if canonical, err := ParseImageType(imageType); err == nil {
+		imageType = canonical
+	}
+	if limit, ok := limits.Types[imageType]; ok {
+		return limit
+	}
+	return limits.Default
+}
+
+//ParseImageSizeLimits parses limits written as a comma-separated list of type=size, like "*=1MiB,.jpg=8MiB,.png=4MiB",
+//where the type * sets the default limit. A size is a number of bytes, or of KiB, MiB or GiB with the suffix,
+//up to 4 GiB less one byte since the size of an uploaded image is sent back as a uint32.
+//The types that are not listed keep the default limits
+func ParseImageSizeLimits(spec string) (ImageSizeLimits, error) {
+	limits := DefaultImageSizeLimits()
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 {
+			return limits, fmt.Errorf("Image size limit %q is not type=size", entry)
+		}
+
+		size, err := parseByteSize(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return limits, fmt.Errorf("Image size limit %q has an invalid size: %v", entry, err)
+		}
+
+		imageType := strings.TrimSpace(parts[0])
+		if imageType == "*" {
+			limits.Default = size
+			continue
+		}
+		imageType, err = ParseImageType(imageType)
+		if err != nil {
+			return limits, fmt.Errorf("Image size limit %q is invalid: %v", entry, err)
+		}
+		if limits.Types == nil {
+			limits.Types = make(map[string]int64)
+		}
+		limits.Types[imageType] = size
+	}
+
+	return limits, nil
+}
+
+func parseByteSize(value string) (int64, error) {
+	units := []struct {
+		suffix string
+		size   int64
+	}{
+		{"GiB", 1 << 30},
+		{"MiB", 1 << 20},
+		{"KiB", 1 << 10},
+	}
+
+	unit := int64(1)
+	for _, u := range units {
+		if strings.HasSuffix(value, u.suffix) {
+			value = strings.TrimSuffix(value, u.suffix)
+			unit = u.size
+			break
+		}
+	}
+
+	size, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if size <= 0 {
+		return 0, fmt.Errorf("size must be positive")
+	}
+	if size > math.MaxUint32/unit {
+		return 0, fmt.Errorf("size is larger than %d bytes", uint32(math.MaxUint32))
+	}
+	return size * unit, nil
 }
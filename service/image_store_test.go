@@ -0,0 +1,33 @@
+package service
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseImageSizeLimits(t *testing.T) {
+	t.Parallel()
+
+	limits, err := ParseImageSizeLimits("")
+	require.NoError(t, err)
+	require.Equal(t, DefaultImageSizeLimits(), limits)
+	require.Equal(t, int64(1<<20), limits.For(".jpg"))
+
+	limits, err = ParseImageSizeLimits("*=512KiB, .JPG=8MiB,.png=1000")
+	require.NoError(t, err)
+	require.Equal(t, int64(512<<10), limits.For(".gif"))
+	require.Equal(t, int64(8<<20), limits.For(".jpg"))
+	require.Equal(t, int64(8<<20), limits.For(".Jpg"))
+	require.Equal(t, int64(1000), limits.For(".png"))
+
+	limits, err = ParseImageSizeLimits("*=4294967295")
+	require.NoError(t, err)
+	require.Equal(t, int64(math.MaxUint32), limits.Default)
+
+	for _, spec := range []string{".jpg", ".jpg=", ".jpg=big", ".jpg=0", ".jpg=-1MiB", ".jpg=1TiB", ".jpg=8589934592GiB", ".jpg=9223372036854775807KiB", ".jpg=4GiB", ".jpg=4294967296"} {
+		_, err = ParseImageSizeLimits(spec)
+		require.Error(t, err, spec)
+	}
+}
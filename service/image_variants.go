@@ -0,0 +1,323 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"demo-grpc/pb"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"log"
+	"sync"
+	"time"
+
+	//registers the WebP decoder with image.Decode
+	_ "golang.org/x/image/webp"
+)
+
+//ImageVariantSizes are the longest sides in pixels of the variants generated for every image
+var ImageVariantSizes = []int{128, 512, 1024}
+
+//maxVariantSize is the size limit of a variant file, which is far above what the largest variant needs
+const maxVariantSize = 64 << 20
+
+//maxVariantPixels is the number of pixels of the largest image that is decoded to generate its variants.
+//A small file can declare a huge image, and decoding it takes 4 or 8 bytes per pixel
+const maxVariantPixels = 50 * 1000 * 1000
+
+//ImageVariant is a resized copy of an image, kept next to the original
+type ImageVariant struct {
+	//MaxSide is the longest side the variant was resized to, one of ImageVariantSizes
+	MaxSide int
+	Type    string
+	Path    string
+	Size    int64
+	Width   int
+	Height  int
+}
+
+//isImageVariantSize tells whether maxSide names a variant, 0 names the original image
+func isImageVariantSize(maxSide int) bool {
+	for _, size := range ImageVariantSizes {
+		if size == maxSide {
+			return true
+		}
+	}
+	return maxSide == 0
+}
+
+//withVariant returns a copy of variants where variant replaces the one with the same MaxSide, ordered by MaxSide
+func withVariant(variants []ImageVariant, variant ImageVariant) []ImageVariant {
+	result := make([]ImageVariant, 0, len(variants)+1)
+	added := false
+	for _, other := range variants {
+		if !added && other.MaxSide >= variant.MaxSide {
+			result = append(result, variant)
+			added = true
+		}
+		if other.MaxSide != variant.MaxSide {
+			result = append(result, other)
+		}
+	}
+	if !added {
+		result = append(result, variant)
+	}
+	return result
+}
+
+//removeVariantFiles removes the variant files of an image
+func removeVariantFiles(info *ImageInfo) error {
+	for _, variant := range info.Variants {
+		err := removeImageFile(variant.Path)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//VariantGenerator generates the variants of the images in the background, with a pool of workers
+type VariantGenerator struct {
+	imageStore ImageStore
+	queue      chan string
+}
+
+//NewVariantGenerator returns a new variant generator that holds at most queueSize images waiting for a worker
+func NewVariantGenerator(imageStore ImageStore, queueSize int) *VariantGenerator {
+	return &VariantGenerator{
+		imageStore: imageStore,
+		queue:      make(chan string, queueSize),
+	}
+}
+
+//Enqueue queues an image for its variants to be generated, it returns false if the queue is full.
+//An image that is not queued gets its variants from the next backfill
+func (generator *VariantGenerator) Enqueue(imageID string) bool {
+	select {
+	case generator.queue <- imageID:
+		return true
+	default:
+		log.Printf("Cannot queue image %s for its variants: the queue is full", imageID)
+		return false
+	}
+}
+
+//Run generates the variants of the queued images with the given number of workers until ctx is done
+func (generator *VariantGenerator) Run(ctx context.Context, workers int) {
+	wg := sync.WaitGroup{}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case imageID := <-generator.queue:
+					err := generator.Generate(imageID)
+					if err != nil {
+						log.Printf("Cannot generate variants of image %s: %v", imageID, err)
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+//RunBackfill queues the images that miss some of their variants right away, then every interval until ctx is done
+func (generator *VariantGenerator) RunBackfill(ctx context.Context, laptopStore LaptopStore, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		queued, err := generator.Backfill(ctx, laptopStore)
+		if err != nil {
+			log.Printf("Cannot backfill image variants: %v", err)
+		}
+		if queued > 0 {
+			log.Printf("Queued %d images for their missing variants", queued)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+//Backfill queues every image of the laptops of laptopStore that misses some of its variants, like the images
+//uploaded before variants were generated or while the queue was full. It waits for room in the queue,
+//and returns how many images it queued
+func (generator *VariantGenerator) Backfill(ctx context.Context, laptopStore LaptopStore) (int, error) {
+	queued := 0
+	err := eachLaptop(ctx, laptopStore, func(laptop *pb.Laptop) error {
+		images, err := generator.imageStore.List(laptop.GetId())
+		if err != nil {
+			return fmt.Errorf("Cannot list images: %v", err)
+		}
+
+		for _, info := range images {
+			if !needsVariants(info) {
+				continue
+			}
+
+			select {
+			case generator.queue <- info.ID:
+				queued++
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		return nil
+	})
+	return queued, err
+}
+
+//needsVariants tells whether an image misses a variant it should have
+func needsVariants(info *ImageInfo) bool {
+	if info.Width*info.Height > maxVariantPixels {
+		return false
+	}
+
+	bounds := image.Rect(0, 0, info.Width, info.Height)
+	for _, maxSide := range ImageVariantSizes {
+		if _, _, ok := variantDimensions(bounds, maxSide); ok && info.Variant(maxSide) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+//Generate generates and saves the variants of an image that are smaller than the image itself.
+//The variant of a JPEG image is a JPEG image, the variant of any other image is a PNG image.
+//An image of more than maxVariantPixels pixels is not decoded and has no variants
+func (generator *VariantGenerator) Generate(imageID string) error {
+	info, err := generator.imageStore.Find(imageID)
+	if err != nil {
+		return err
+	}
+	if info == nil {
+		return nil
+	}
+	if info.Width*info.Height > maxVariantPixels {
+		return fmt.Errorf("Image has %dx%d pixels, more than %d", info.Width, info.Height, maxVariantPixels)
+	}
+
+	file, err := generator.imageStore.Open(imageID)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return fmt.Errorf("Cannot decode image: %v", err)
+	}
+
+	for _, maxSide := range ImageVariantSizes {
+		width, height, ok := variantDimensions(img.Bounds(), maxSide)
+		if !ok {
+			continue
+		}
+
+		data := bytes.Buffer{}
+		variantType := ".png"
+		if info.Type == ".jpg" {
+			variantType = ".jpg"
+			err = jpeg.Encode(&data, resizeImage(img, width, height), &jpeg.Options{Quality: 85})
+		} else {
+			err = png.Encode(&data, resizeImage(img, width, height))
+		}
+		if err != nil {
+			return fmt.Errorf("Cannot encode %dpx variant: %v", maxSide, err)
+		}
+
+		err = generator.saveVariant(imageID, maxSide, variantType, &data)
+		if err != nil {
+			return fmt.Errorf("Cannot save %dpx variant: %v", maxSide, err)
+		}
+	}
+
+	log.Printf("Generated variants of image %s", imageID)
+	return nil
+}
+
+func (generator *VariantGenerator) saveVariant(imageID string, maxSide int, variantType string, data io.Reader) error {
+	staged, err := generator.imageStore.Stage(variantType, data, maxVariantSize)
+	if err != nil {
+		return err
+	}
+	defer staged.Discard()
+
+	return generator.imageStore.SaveVariant(imageID, maxSide, staged)
+}
+
+//variantDimensions returns the dimensions of an image resized so that its longest side is maxSide,
+//ok is false if the image is not larger than that
+func variantDimensions(bounds image.Rectangle, maxSide int) (width int, height int, ok bool) {
+	width, height = bounds.Dx(), bounds.Dy()
+	if width <= maxSide && height <= maxSide {
+		return width, height, false
+	}
+
+	if width >= height {
+		height = height * maxSide / width
+		width = maxSide
+	} else {
+		width = width * maxSide / height
+		height = maxSide
+	}
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+	return width, height, true
+}
+
+//resizeImage shrinks an image to width and height, each pixel is the average of the pixels of the image it covers
+func resizeImage(src image.Image, width int, height int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	bounds := src.Bounds()
+
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := bounds.Min.Y + (y+1)*bounds.Dy()/height
+		if y1 == y0 {
+			y1++
+		}
+
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := bounds.Min.X + (x+1)*bounds.Dx()/width
+			if x1 == x0 {
+				x1++
+			}
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r += uint64(cr)
+					g += uint64(cg)
+					b += uint64(cb)
+					a += uint64(ca)
+					n++
+				}
+			}
+
+			i := dst.PixOffset(x, y)
+			dst.Pix[i+0] = uint8(r / n >> 8)
+			dst.Pix[i+1] = uint8(g / n >> 8)
+			dst.Pix[i+2] = uint8(b / n >> 8)
+			dst.Pix[i+3] = uint8(a / n >> 8)
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"demo-grpc/sample"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateImageVariants(t *testing.T) {
+	t.Parallel()
+
+	folder, err := ioutil.TempDir("", "images")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(folder) })
+
+	imageStore := NewDiskImageStore(folder)
+	generator := NewVariantGenerator(imageStore, 1)
+
+	large, err := SaveImage(imageStore, "laptop", ".png", bytes.NewBuffer(sample.NewImage(".png", 1000, 1500)), 16<<20)
+	require.NoError(t, err)
+	require.NoError(t, generator.Generate(large))
+
+	info, err := imageStore.Find(large)
+	require.NoError(t, err)
+	require.Len(t, info.Variants, 3)
+	for i, size := range []struct{ maxSide, width, height int }{{128, 85, 128}, {512, 341, 512}, {1024, 682, 1024}} {
+		variant := info.Variants[i]
+		require.Equal(t, size.maxSide, variant.MaxSide)
+		require.Equal(t, ".png", variant.Type)
+		require.Equal(t, size.width, variant.Width)
+		require.Equal(t, size.height, variant.Height)
+	}
+
+	//an image no larger than a variant size gets no variant of that size or larger
+	small, err := SaveImage(imageStore, "laptop", ".gif", bytes.NewBuffer(sample.NewImage(".gif", 128, 20)), 1<<20)
+	require.NoError(t, err)
+	require.NoError(t, generator.Generate(small))
+	info, err = imageStore.Find(small)
+	require.NoError(t, err)
+	require.Empty(t, info.Variants)
+
+	//an image that is gone has no variants to generate
+	require.NoError(t, imageStore.Delete(large))
+	require.NoError(t, generator.Generate(large))
+}
+
+func TestGenerateWebPImageVariants(t *testing.T) {
+	t.Parallel()
+
+	folder, err := ioutil.TempDir("", "images")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(folder) })
+
+	file, err := os.Open("../tmp/laptop.webp")
+	require.NoError(t, err)
+	defer file.Close()
+
+	imageStore := NewDiskImageStore(folder)
+	imageID, err := SaveImage(imageStore, "laptop", ".webp", file, 1<<20)
+	require.NoError(t, err)
+	require.NoError(t, NewVariantGenerator(imageStore, 1).Generate(imageID))
+
+	//WebP variants are PNG images, as there is no WebP encoder
+	info, err := imageStore.Find(imageID)
+	require.NoError(t, err)
+	require.Len(t, info.Variants, 3)
+	require.Equal(t, ".png", info.Variants[2].Type)
+	require.Equal(t, 1024, info.Variants[2].Width)
+	require.Equal(t, 535, info.Variants[2].Height)
+}
+
+func TestGenerateImageVariantsPixelLimit(t *testing.T) {
+	t.Parallel()
+
+	folder, err := ioutil.TempDir("", "images")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(folder) })
+
+	//a small PNG image whose header declares 30000x30000 pixels
+	data := sample.NewImage(".png", 8, 8)
+	binary.BigEndian.PutUint32(data[16:20], 30000)
+	binary.BigEndian.PutUint32(data[20:24], 30000)
+	binary.BigEndian.PutUint32(data[29:33], crc32.ChecksumIEEE(data[12:29]))
+
+	imageStore := NewDiskImageStore(folder)
+	imageID, err := SaveImage(imageStore, "laptop", ".png", bytes.NewBuffer(data), 1<<20)
+	require.NoError(t, err)
+
+	info, err := imageStore.Find(imageID)
+	require.NoError(t, err)
+	require.Equal(t, 30000, info.Width)
+	require.False(t, needsVariants(info))
+
+	require.Error(t, NewVariantGenerator(imageStore, 1).Generate(imageID))
+	info, err = imageStore.Find(imageID)
+	require.NoError(t, err)
+	require.Empty(t, info.Variants)
+}
+
+func TestGeneratorQueueFull(t *testing.T) {
+	t.Parallel()
+
+	generator := NewVariantGenerator(NewDiskImageStore(""), 1)
+	require.True(t, generator.Enqueue("first"))
+	require.False(t, generator.Enqueue("second"))
+}
+
+func TestBackfillImageVariants(t *testing.T) {
+	t.Parallel()
+
+	folder, err := ioutil.TempDir("", "images")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(folder) })
+
+	laptopStore := NewInMemoryLaptopStore()
+	laptop := sample.NewLaptop()
+	require.NoError(t, laptopStore.Save(laptop))
+
+	imageStore := NewDiskImageStore(folder)
+	large, err := SaveImage(imageStore, laptop.Id, ".png", bytes.NewBuffer(sample.NewImage(".png", 300, 200)), 1<<20)
+	require.NoError(t, err)
+	_, err = SaveImage(imageStore, laptop.Id, ".png", bytes.NewBuffer(sample.NewImage(".png", 100, 100)), 1<<20)
+	require.NoError(t, err)
+
+	//only the image larger than a variant is queued, and only until its variants are generated
+	generator := NewVariantGenerator(imageStore, 10)
+	queued, err := generator.Backfill(context.Background(), laptopStore)
+	require.NoError(t, err)
+	require.Equal(t, 1, queued)
+	require.Equal(t, large, <-generator.queue)
+
+	require.NoError(t, generator.Generate(large))
+	queued, err = generator.Backfill(context.Background(), laptopStore)
+	require.NoError(t, err)
+	require.Equal(t, 0, queued)
+
+	//a backfill waits for room in a full queue
+	require.NoError(t, imageStore.Delete(large))
+	large, err = SaveImage(imageStore, laptop.Id, ".png", bytes.NewBuffer(sample.NewImage(".png", 300, 200)), 1<<20)
+	require.NoError(t, err)
+	full := NewVariantGenerator(imageStore, 1)
+	require.True(t, full.Enqueue("other"))
+	done := make(chan int)
+	go func() {
+		queued, err := full.Backfill(context.Background(), laptopStore)
+		require.NoError(t, err)
+		done <- queued
+	}()
+	require.Equal(t, "other", <-full.queue)
+	require.Equal(t, 1, <-done)
+	require.Equal(t, large, <-full.queue)
+}
+
+func TestResizeImage(t *testing.T) {
+	t.Parallel()
+
+	src := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for x := 0; x < 4; x++ {
+		for y := 0; y < 2; y++ {
+			src.Set(x, y, color.RGBA{R: uint8(x%2) * 200, A: 255})
+		}
+	}
+
+	dst := resizeImage(src, 2, 1)
+	require.Equal(t, image.Rect(0, 0, 2, 1), dst.Bounds())
+	require.Equal(t, color.RGBA{R: 100, A: 255}, dst.At(0, 0))
+	require.Equal(t, color.RGBA{R: 100, A: 255}, dst.At(1, 0))
+
+	_, _, ok := variantDimensions(image.Rect(0, 0, 512, 300), 512)
+	require.False(t, ok)
+	width, height, ok := variantDimensions(image.Rect(0, 0, 3000, 2), 128)
+	require.True(t, ok)
+	require.Equal(t, 128, width)
+	require.Equal(t, 1, height)
+}
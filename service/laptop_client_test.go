@@ -2,19 +2,27 @@ package service
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"demo-grpc/pb"
 	"demo-grpc/sample"
 	"demo-grpc/serializer"
 	"fmt"
+	"image/jpeg"
 	"io"
+	"io/ioutil"
 	"net"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestClientCreateLaptop(t *testing.T) {
@@ -56,7 +64,7 @@ func TestClientUploadImage(t *testing.T) {
 	serverAddress := startTestLaptopServer(t, laptopStore, imageStore, nil)
 	laptopClient := newTestLaptopClient(t, serverAddress)
 
-	imagePath := fmt.Sprintf("%s/laptop.jpg", testImageFolder)
+	imagePath := fmt.Sprintf("%s/laptop.webp", testImageFolder)
 	file, err := os.Open(imagePath)
 	require.NoError(t, err)
 	defer file.Close()
@@ -109,6 +117,303 @@ func TestClientUploadImage(t *testing.T) {
 	require.NoError(t, os.Remove(savedImagePath))
 }
 
+func TestClientUploadImageValidation(t *testing.T) {
+	t.Parallel()
+
+	folder, err := ioutil.TempDir("", "images")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(folder) })
+
+	laptopStore := NewInMemoryLaptopStore()
+	imageStore := NewDiskImageStore(folder)
+
+	laptop := sample.NewLaptop()
+	require.NoError(t, laptopStore.Save(laptop))
+
+	laptopServer := NewLaptopServer(laptopStore, imageStore, nil, nil, nil, nil)
+	laptopServer.SetImageSizeLimits(ImageSizeLimits{Default: 1000, Types: map[string]int64{".png": 1 << 20}})
+	laptopClient := newTestLaptopClient(t, serveTestLaptopServer(t, laptopServer))
+
+	uploadImage := func(imageType string, data []byte) (*pb.UploadImageResponse, error) {
+		stream, err := laptopClient.UploadImage(context.Background())
+		require.NoError(t, err)
+
+		info := &pb.ImageInfo{LaptopId: laptop.Id, ImageType: imageType}
+		require.NoError(t, stream.Send(&pb.UploadImageRequest{Data: &pb.UploadImageRequest_Info{Info: info}}))
+		for len(data) > 0 {
+			n := 1024
+			if n > len(data) {
+				n = len(data)
+			}
+			err = stream.Send(&pb.UploadImageRequest{Data: &pb.UploadImageRequest_ChunkData{ChunkData: data[:n]}})
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			data = data[n:]
+		}
+		return stream.CloseAndRecv()
+	}
+
+	jpg := sample.NewImage(".jpg", 64, 64)
+	png := sample.NewImage(".png", 64, 64)
+
+	//a stream that ends before the image info is not taken for a missing laptop
+	stream, err := laptopClient.UploadImage(context.Background())
+	require.NoError(t, err)
+	_, err = stream.CloseAndRecv()
+	require.Equal(t, codes.Unknown, status.Code(err))
+
+	//a JPEG image only has the default limit
+	_, err = uploadImage(".jpg", jpg)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	for _, imageType := range []string{".gif", ".exe", "/../../x"} {
+		_, err = uploadImage(imageType, png)
+		require.Equal(t, codes.InvalidArgument, status.Code(err), imageType)
+	}
+
+	res, err := uploadImage(".PNG", png)
+	require.NoError(t, err)
+	require.Equal(t, uint32(len(png)), res.GetSize())
+
+	//only the valid image is written
+	files, err := ioutil.ReadDir(folder)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	require.Equal(t, res.GetId()+".png", files[0].Name())
+
+	list, err := laptopClient.ListImages(context.Background(), &pb.ListImagesRequest{LaptopId: laptop.Id})
+	require.NoError(t, err)
+	require.Len(t, list.GetImages(), 1)
+	require.Equal(t, ".png", list.GetImages()[0].GetImageType())
+	require.Equal(t, uint32(64), list.GetImages()[0].GetWidth())
+	require.Equal(t, uint32(64), list.GetImages()[0].GetHeight())
+}
+
+func TestClientResumableUpload(t *testing.T) {
+	t.Parallel()
+
+	folder, err := ioutil.TempDir("", "upload")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(folder) })
+
+	laptopStore := NewInMemoryLaptopStore()
+	imageStore := NewDiskImageStore(folder)
+	uploadStore, err := NewDiskUploadStore(filepath.Join(folder, "uploads"))
+	require.NoError(t, err)
+
+	laptop := sample.NewLaptop()
+	require.NoError(t, laptopStore.Save(laptop))
+
+	serverAddress := serveTestLaptopServer(t, NewLaptopServer(laptopStore, imageStore, nil, nil, nil, uploadStore))
+	laptopClient := newTestLaptopClient(t, serverAddress)
+
+	sendChunks := func(uploadID string, offset uint64, chunks ...[]byte) (*pb.UploadChunksResponse, error) {
+		stream, err := laptopClient.UploadChunks(context.Background())
+		require.NoError(t, err)
+
+		position := &pb.UploadChunksRequest_Position{UploadId: uploadID, Offset: offset}
+		require.NoError(t, stream.Send(&pb.UploadChunksRequest{Data: &pb.UploadChunksRequest_Position_{Position: position}}))
+		for _, chunk := range chunks {
+			err = stream.Send(&pb.UploadChunksRequest{Data: &pb.UploadChunksRequest_ChunkData{ChunkData: chunk}})
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+		}
+		return stream.CloseAndRecv()
+	}
+
+	_, err = laptopClient.StartUpload(context.Background(), &pb.StartUploadRequest{Info: &pb.ImageInfo{LaptopId: "missing", ImageType: ".jpg"}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	started, err := laptopClient.StartUpload(context.Background(), &pb.StartUploadRequest{Info: &pb.ImageInfo{LaptopId: laptop.Id, ImageType: ".jpg"}})
+	require.NoError(t, err)
+	uploadID := started.GetUploadId()
+
+	image := sample.NewImage(".jpg", 16, 16)
+	size := uint64(len(image))
+
+	//the first stream breaks after two chunks
+	res, err := sendChunks(uploadID, 0, image[:100], image[100:200])
+	require.NoError(t, err)
+	require.Equal(t, uint64(200), res.GetCommittedSize())
+
+	query, err := laptopClient.QueryUpload(context.Background(), &pb.QueryUploadRequest{UploadId: uploadID})
+	require.NoError(t, err)
+	require.Equal(t, laptop.Id, query.GetLaptopId())
+	require.Equal(t, ".jpg", query.GetImageType())
+	require.Equal(t, uint64(200), query.GetCommittedSize())
+
+	_, err = laptopClient.FinishUpload(context.Background(), &pb.FinishUploadRequest{UploadId: uploadID, Size: size})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = sendChunks(uploadID, 300, image[300:])
+	require.Equal(t, codes.OutOfRange, status.Code(err))
+
+	//the resumed stream resends part of the second chunk
+	res, err = sendChunks(uploadID, 150, image[150:250], image[250:])
+	require.NoError(t, err)
+	require.Equal(t, size, res.GetCommittedSize())
+
+	finished, err := laptopClient.FinishUpload(context.Background(), &pb.FinishUploadRequest{UploadId: uploadID, Size: size})
+	require.NoError(t, err)
+	require.Equal(t, uint32(size), finished.GetSize())
+
+	file, err := imageStore.Open(finished.GetId())
+	require.NoError(t, err)
+	data, err := ioutil.ReadAll(file)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	require.Equal(t, image, data)
+
+	_, err = laptopClient.QueryUpload(context.Background(), &pb.QueryUploadRequest{UploadId: uploadID})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestClientDownloadImage(t *testing.T) {
+	t.Parallel()
+
+	folder, err := ioutil.TempDir("", "images")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(folder) })
+
+	laptopStore := NewInMemoryLaptopStore()
+	imageStore := NewDiskImageStore(folder)
+
+	laptop := sample.NewLaptop()
+	require.NoError(t, laptopStore.Save(laptop))
+
+	//the image is larger than one chunk
+	data := sample.NewImage(".png", 256, 256)
+	imageID, err := SaveImage(imageStore, laptop.Id, ".png", bytes.NewBuffer(data), 1<<20)
+	require.NoError(t, err)
+
+	serverAddress := startTestLaptopServer(t, laptopStore, imageStore, nil)
+	laptopClient := newTestLaptopClient(t, serverAddress)
+
+	list, err := laptopClient.ListImages(context.Background(), &pb.ListImagesRequest{LaptopId: laptop.Id})
+	require.NoError(t, err)
+	expected := &pb.ImageMetadata{Id: imageID, LaptopId: laptop.Id, ImageType: ".png", Size: uint64(len(data)), Width: 256, Height: 256}
+	require.Len(t, list.GetImages(), 1)
+	require.True(t, proto.Equal(expected, list.GetImages()[0]))
+
+	_, err = laptopClient.ListImages(context.Background(), &pb.ListImagesRequest{LaptopId: "missing"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	stream, err := laptopClient.DownloadImage(context.Background(), &pb.DownloadImageRequest{ImageId: imageID})
+	require.NoError(t, err)
+
+	res, err := stream.Recv()
+	require.NoError(t, err)
+	require.True(t, proto.Equal(expected, res.GetInfo()))
+
+	downloaded := bytes.Buffer{}
+	chunks := 0
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(res.GetChunkData()), imageChunkSize)
+		downloaded.Write(res.GetChunkData())
+		chunks++
+	}
+	require.Equal(t, data, downloaded.Bytes())
+	require.Greater(t, chunks, 1)
+
+	stream, err = laptopClient.DownloadImage(context.Background(), &pb.DownloadImageRequest{ImageId: "missing"})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestClientImageVariants(t *testing.T) {
+	t.Parallel()
+
+	folder, err := ioutil.TempDir("", "images")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(folder) })
+
+	laptopStore := NewInMemoryLaptopStore()
+	imageStore := NewDiskImageStore(folder)
+
+	laptop := sample.NewLaptop()
+	require.NoError(t, laptopStore.Save(laptop))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	generator := NewVariantGenerator(imageStore, 10)
+	go generator.Run(ctx, 2)
+
+	laptopServer := NewLaptopServer(laptopStore, imageStore, nil, nil, nil, nil)
+	laptopServer.SetVariantGenerator(generator)
+	laptopClient := newTestLaptopClient(t, serveTestLaptopServer(t, laptopServer))
+
+	stream, err := laptopClient.UploadImage(context.Background())
+	require.NoError(t, err)
+	info := &pb.ImageInfo{LaptopId: laptop.Id, ImageType: ".jpg"}
+	require.NoError(t, stream.Send(&pb.UploadImageRequest{Data: &pb.UploadImageRequest_Info{Info: info}}))
+	data := sample.NewImage(".jpg", 600, 400)
+	require.NoError(t, stream.Send(&pb.UploadImageRequest{Data: &pb.UploadImageRequest_ChunkData{ChunkData: data}}))
+	res, err := stream.CloseAndRecv()
+	require.NoError(t, err)
+	imageID := res.GetId()
+
+	//the variants are generated in the background
+	require.Eventually(t, func() bool {
+		info, err := imageStore.Find(imageID)
+		return err == nil && len(info.Variants) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	list, err := laptopClient.ListImages(context.Background(), &pb.ListImagesRequest{LaptopId: laptop.Id, Variant: 512})
+	require.NoError(t, err)
+	require.Len(t, list.GetImages(), 1)
+	variant := list.GetImages()[0]
+	require.Equal(t, imageID, variant.GetId())
+	require.Equal(t, uint32(512), variant.GetVariant())
+	require.Equal(t, ".jpg", variant.GetImageType())
+	require.Equal(t, uint32(512), variant.GetWidth())
+	require.Equal(t, uint32(341), variant.GetHeight())
+
+	//the image is smaller than the largest variant, which is the image itself
+	list, err = laptopClient.ListImages(context.Background(), &pb.ListImagesRequest{LaptopId: laptop.Id, Variant: 1024})
+	require.NoError(t, err)
+	original := &pb.ImageMetadata{Id: imageID, LaptopId: laptop.Id, ImageType: ".jpg", Size: uint64(len(data)), Width: 600, Height: 400}
+	require.True(t, proto.Equal(original, list.GetImages()[0]))
+
+	_, err = laptopClient.ListImages(context.Background(), &pb.ListImagesRequest{LaptopId: laptop.Id, Variant: 100})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	download, err := laptopClient.DownloadImage(context.Background(), &pb.DownloadImageRequest{ImageId: imageID, Variant: 128})
+	require.NoError(t, err)
+	first, err := download.Recv()
+	require.NoError(t, err)
+	require.Equal(t, uint32(128), first.GetInfo().GetVariant())
+
+	downloaded := bytes.Buffer{}
+	for {
+		res, err := download.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		downloaded.Write(res.GetChunkData())
+	}
+	require.Equal(t, first.GetInfo().GetSize(), uint64(downloaded.Len()))
+	config, err := jpeg.DecodeConfig(&downloaded)
+	require.NoError(t, err)
+	require.Equal(t, 128, config.Width)
+	require.Equal(t, 85, config.Height)
+
+	download, err = laptopClient.DownloadImage(context.Background(), &pb.DownloadImageRequest{ImageId: imageID, Variant: 100})
+	require.NoError(t, err)
+	_, err = download.Recv()
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
 func TestClientRateLaptop(t *testing.T) {
 	t.Parallel()
 
@@ -156,8 +461,11 @@ func TestClientRateLaptop(t *testing.T) {
 }
 
 func startTestLaptopServer(t *testing.T, laptopStore LaptopStore, imageStore ImageStore, ratingStore RatingStore) string {
-	laptopServer := NewLaptopServer(laptopStore, imageStore, ratingStore)
+	laptopServer := NewLaptopServer(laptopStore, imageStore, ratingStore, nil, nil, nil)
+	return serveTestLaptopServer(t, laptopServer)
+}
 
+func serveTestLaptopServer(t *testing.T, laptopServer *LaptopServer) string {
 	grpcServer := grpc.NewServer()
 	pb.RegisterLaptopServiceServer(grpcServer, laptopServer)
 
@@ -240,6 +548,263 @@ func TestClientSearchLaptop(t *testing.T) {
 	//require.Equal(t, len(expectedIDs), found)
 }
 
+func TestClientSearchLaptopQuery(t *testing.T) {
+	t.Parallel()
+
+	store := NewInMemoryLaptopStore()
+	expectedIDs := make(map[string]bool)
+
+	for i := 0; i < 6; i++ {
+		laptop := sample.NewLaptop()
+		laptop.Brand = "Lenovo"
+		laptop.Screen.Panel = pb.Screen_IPS
+		if i%2 == 0 {
+			laptop.Brand = "Dell"
+			expectedIDs[laptop.Id] = true
+		}
+		require.NoError(t, store.Save(laptop))
+	}
+
+	serverAddress := startTestLaptopServer(t, store, nil, nil)
+	laptopClient := newTestLaptopClient(t, serverAddress)
+
+	req := &pb.SearchLaptopRequest{
+		Query: "(brand = Apple OR brand = Dell) AND NOT panel = OLED",
+	}
+
+	stream, err := laptopClient.SearchLaptop(context.Background(), req)
+	require.NoError(t, err)
+
+	found := 0
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		require.NoError(t, err)
+		require.Contains(t, expectedIDs, res.GetLaptop().GetId())
+		found++
+	}
+	require.Equal(t, len(expectedIDs), found)
+
+	stream, err = laptopClient.SearchLaptop(context.Background(), &pb.SearchLaptopRequest{Query: "brand = "})
+	require.NoError(t, err)
+
+	_, err = stream.Recv()
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestClientWatchLaptops(t *testing.T) {
+	t.Parallel()
+
+	store := NewInMemoryLaptopStore()
+	existing := sample.NewLaptop()
+	existing.Brand = "Dell"
+	require.NoError(t, store.Save(existing))
+	unwatched := sample.NewLaptop()
+	unwatched.Brand = "Apple"
+	require.NoError(t, store.Save(unwatched))
+
+	serverAddress := startTestLaptopServer(t, store, nil, nil)
+	laptopClient := newTestLaptopClient(t, serverAddress)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req := &pb.WatchLaptopsRequest{
+		Filter:      &pb.Filter{Brand: "Dell"},
+		SendInitial: true,
+	}
+	stream, err := laptopClient.WatchLaptops(ctx, req)
+	require.NoError(t, err)
+
+	res, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, pb.LaptopEvent_EXISTING, res.GetEvent().GetType())
+	require.Equal(t, existing.Id, res.GetEvent().GetLaptop().GetId())
+
+	other := sample.NewLaptop()
+	other.Brand = "Lenovo"
+	require.NoError(t, store.Save(other))
+
+	created := sample.NewLaptop()
+	created.Brand = "Dell"
+	require.NoError(t, store.Save(created))
+
+	created.Brand = "Lenovo"
+	_, err = store.Update(created, 0)
+	require.NoError(t, err)
+	require.NoError(t, store.Delete(existing.Id, 0))
+
+	expected := []struct {
+		eventType pb.LaptopEvent_Type
+		id        string
+		version   uint64
+	}{
+		{pb.LaptopEvent_CREATED, created.Id, 1},
+		{pb.LaptopEvent_LEFT, created.Id, 2},
+		{pb.LaptopEvent_DELETED, existing.Id, 1},
+	}
+
+	for _, event := range expected {
+		res, err := stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, event.eventType, res.GetEvent().GetType())
+		require.Equal(t, event.id, res.GetEvent().GetLaptop().GetId())
+		require.Equal(t, event.version, res.GetEvent().GetVersion())
+	}
+}
+
+func TestClientExportImportCatalog(t *testing.T) {
+	t.Parallel()
+
+	laptopStore := NewInMemoryLaptopStore()
+	ratingStore := NewInMemoryRatingStore()
+	imageStore := NewDiskImageStore("../tmp")
+
+	laptops := []*pb.Laptop{sample.NewLaptop(), sample.NewLaptop()}
+	for _, laptop := range laptops {
+		require.NoError(t, laptopStore.Save(laptop))
+	}
+	_, err := ratingStore.Add(laptops[0].Id, 6)
+	require.NoError(t, err)
+	_, err = ratingStore.Add(laptops[0].Id, 8)
+	require.NoError(t, err)
+	imageID := uuid.New().String()
+	image := &ImageInfo{ID: imageID, LaptopID: laptops[1].Id, Type: ".jpg", Path: "../tmp/" + imageID + ".jpg"}
+	require.NoError(t, imageStore.SaveInfo(image))
+
+	serverAddress := startTestLaptopServer(t, laptopStore, imageStore, ratingStore)
+	laptopClient := newTestLaptopClient(t, serverAddress)
+
+	stream, err := laptopClient.ExportCatalog(context.Background(), &pb.ExportCatalogRequest{})
+	require.NoError(t, err)
+
+	entries := []*pb.CatalogEntry{}
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		entries = append(entries, res.GetEntry())
+	}
+	require.Len(t, entries, 2)
+
+	otherLaptopStore := NewInMemoryLaptopStore()
+	otherRatingStore := NewInMemoryRatingStore()
+	otherImageStore := NewDiskImageStore("../tmp")
+	existing := proto.Clone(laptops[1]).(*pb.Laptop)
+	existing.Name = "Existing"
+	require.NoError(t, otherLaptopStore.Save(existing))
+
+	serverAddress = startTestLaptopServer(t, otherLaptopStore, otherImageStore, otherRatingStore)
+	laptopClient = newTestLaptopClient(t, serverAddress)
+
+	importCatalog := func(mode pb.ImportCatalogRequest_Mode, entries []*pb.CatalogEntry) *pb.ImportCatalogResponse {
+		stream, err := laptopClient.ImportCatalog(context.Background())
+		require.NoError(t, err)
+
+		require.NoError(t, stream.Send(&pb.ImportCatalogRequest{Data: &pb.ImportCatalogRequest_ImportMode{ImportMode: mode}}))
+		for _, entry := range entries {
+			require.NoError(t, stream.Send(&pb.ImportCatalogRequest{Data: &pb.ImportCatalogRequest_Entry{Entry: entry}}))
+		}
+
+		res, err := stream.CloseAndRecv()
+		require.NoError(t, err)
+		return res
+	}
+
+	invalid := &pb.CatalogEntry{Laptop: &pb.Laptop{Id: "invalid"}}
+	res := importCatalog(pb.ImportCatalogRequest_SKIP_EXISTING, append(entries, invalid))
+	require.Equal(t, uint32(1), res.GetCreated())
+	require.Equal(t, uint32(1), res.GetSkipped())
+	require.Equal(t, uint32(1), res.GetFailed())
+	require.Equal(t, "invalid", res.GetFailures()[0].GetLaptopId())
+
+	found, err := otherLaptopStore.Find(laptops[1].Id)
+	require.NoError(t, err)
+	require.Equal(t, "Existing", found.GetName())
+
+	rating, err := otherRatingStore.Find(laptops[0].Id)
+	require.NoError(t, err)
+	require.Equal(t, &Rating{Count: 2, Sum: 14}, rating)
+
+	res = importCatalog(pb.ImportCatalogRequest_UPSERT, entries)
+	require.Equal(t, uint32(2), res.GetUpdated())
+
+	found, err = otherLaptopStore.Find(laptops[1].Id)
+	require.NoError(t, err)
+	requireSameLaptop(t, laptops[1], found)
+
+	images, err := otherImageStore.List(laptops[1].Id)
+	require.NoError(t, err)
+	require.Equal(t, []*ImageInfo{image}, images)
+
+	//an image cannot be moved to another laptop, nor be given a file of another name
+	withImage, withoutImage := entries[0], entries[1]
+	if withImage.GetLaptop().GetId() != laptops[1].Id {
+		withImage, withoutImage = withoutImage, withImage
+	}
+	moved := proto.Clone(withoutImage).(*pb.CatalogEntry)
+	moved.Images = withImage.GetImages()
+	renamed := proto.Clone(withImage).(*pb.CatalogEntry)
+	renamed.Images[0].Path = "../tmp/other.jpg"
+	outside := proto.Clone(withImage).(*pb.CatalogEntry)
+	outside.Images[0].Path = "../../etc/passwd"
+	res = importCatalog(pb.ImportCatalogRequest_UPSERT, []*pb.CatalogEntry{moved, renamed, outside})
+	require.Equal(t, uint32(3), res.GetFailed())
+	require.Contains(t, res.GetFailures()[2].GetMessage(), "../../etc/passwd")
+
+	images, err = otherImageStore.List(laptops[0].Id)
+	require.NoError(t, err)
+	require.Empty(t, images)
+	images, err = otherImageStore.List(laptops[1].Id)
+	require.NoError(t, err)
+	require.Equal(t, []*ImageInfo{image}, images)
+}
+
+func TestClientImportTrashedLaptop(t *testing.T) {
+	t.Parallel()
+
+	laptopStore := NewInMemoryLaptopStore()
+	trashStore := NewInMemoryTrashStore()
+	laptopServer := NewLaptopServer(laptopStore, nil, nil, NewInMemoryRevisionStore(), trashStore, nil)
+	laptopClient := newTestLaptopClient(t, serveTestLaptopServer(t, laptopServer))
+
+	laptop := sample.NewLaptop()
+	_, err := laptopClient.CreateLaptop(context.Background(), &pb.CreateLaptopRequest{Laptop: laptop})
+	require.NoError(t, err)
+	_, err = laptopClient.DeleteLaptop(context.Background(), &pb.DeleteLaptopRequest{Id: laptop.Id})
+	require.NoError(t, err)
+
+	//a trashed laptop keeps its ID, so an import cannot bring it back beside the trash
+	for _, mode := range []pb.ImportCatalogRequest_Mode{pb.ImportCatalogRequest_SKIP_EXISTING, pb.ImportCatalogRequest_UPSERT} {
+		stream, err := laptopClient.ImportCatalog(context.Background())
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&pb.ImportCatalogRequest{Data: &pb.ImportCatalogRequest_ImportMode{ImportMode: mode}}))
+		require.NoError(t, stream.Send(&pb.ImportCatalogRequest{Data: &pb.ImportCatalogRequest_Entry{Entry: &pb.CatalogEntry{Laptop: laptop}}}))
+
+		res, err := stream.CloseAndRecv()
+		require.NoError(t, err)
+		require.EqualValues(t, 0, res.GetCreated())
+		require.EqualValues(t, 1, res.GetFailed())
+		require.Contains(t, res.GetFailures()[0].GetMessage(), "is in the trash")
+	}
+
+	//nor can an old revision of it be restored
+	_, err = laptopClient.RestoreLaptopRevision(context.Background(), &pb.RestoreLaptopRevisionRequest{LaptopId: laptop.Id, Revision: 1})
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	found, err := laptopStore.Find(laptop.Id)
+	require.NoError(t, err)
+	require.Nil(t, found)
+
+	_, err = laptopClient.RestoreTrashedLaptop(context.Background(), &pb.RestoreTrashedLaptopRequest{Id: laptop.Id})
+	require.NoError(t, err)
+}
+
 func newTestLaptopClient(t *testing.T, serverAddress string) pb.LaptopServiceClient {
 	conn, err := grpc.Dial(serverAddress, grpc.WithInsecure())
 	require.NoError(t, err)
@@ -256,3 +821,133 @@ func requireSameLaptop(t *testing.T, laptop1 *pb.Laptop, laptop2 *pb.Laptop) {
 
 	require.Equal(t, json1, json2)
 }
+
+func TestClientCreateLaptops(t *testing.T) {
+	t.Parallel()
+
+	laptopStore := NewInMemoryLaptopStore()
+	existing := sample.NewLaptop()
+	require.NoError(t, laptopStore.Save(existing))
+
+	serverAddress := startTestLaptopServer(t, laptopStore, nil, nil)
+	laptopClient := newTestLaptopClient(t, serverAddress)
+
+	createLaptops := func(allOrNothing bool, laptops []*pb.Laptop) ([]*pb.CreateLaptopsResponse, error) {
+		stream, err := laptopClient.CreateLaptops(context.Background())
+		require.NoError(t, err)
+
+		require.NoError(t, stream.Send(&pb.CreateLaptopsRequest{Data: &pb.CreateLaptopsRequest_Options_{
+			Options: &pb.CreateLaptopsRequest_Options{AllOrNothing: allOrNothing},
+		}}))
+		for _, laptop := range laptops {
+			err = stream.Send(&pb.CreateLaptopsRequest{Data: &pb.CreateLaptopsRequest_Laptop{Laptop: laptop}})
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+		}
+		require.NoError(t, stream.CloseSend())
+
+		results := []*pb.CreateLaptopsResponse{}
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				return results, nil
+			}
+			if err != nil {
+				return results, err
+			}
+			results = append(results, res)
+		}
+	}
+
+	noID := sample.NewLaptop()
+	noID.Id = ""
+	laptops := []*pb.Laptop{sample.NewLaptop(), {Id: "invalid"}, existing, noID}
+
+	results, err := createLaptops(false, laptops)
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+
+	expected := []codes.Code{codes.OK, codes.InvalidArgument, codes.AlreadyExists, codes.OK}
+	for i, res := range results {
+		require.Equal(t, uint32(i), res.GetIndex())
+		require.Equal(t, uint32(expected[i]), res.GetCode(), res.GetMessage())
+	}
+
+	require.Equal(t, laptops[0].Id, results[0].GetId())
+	require.Equal(t, uint64(1), results[0].GetVersion())
+	require.NotEmpty(t, results[3].GetId())
+	found, err := laptopStore.Find(results[3].GetId())
+	require.NoError(t, err)
+	require.NotNil(t, found)
+
+	//the in-memory store cannot save laptops in a single transaction
+	_, err = createLaptops(true, []*pb.Laptop{sample.NewLaptop()})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestClientCreateLaptopsAllOrNothing(t *testing.T) {
+	t.Parallel()
+
+	folder, err := ioutil.TempDir("", "bolt")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(folder) })
+
+	db, err := OpenBoltDB(filepath.Join(folder, "data.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	laptopStore := NewBoltLaptopStore(db)
+	serverAddress := startTestLaptopServer(t, laptopStore, nil, nil)
+	laptopClient := newTestLaptopClient(t, serverAddress)
+
+	createLaptops := func(laptops []*pb.Laptop) []*pb.CreateLaptopsResponse {
+		stream, err := laptopClient.CreateLaptops(context.Background())
+		require.NoError(t, err)
+
+		require.NoError(t, stream.Send(&pb.CreateLaptopsRequest{Data: &pb.CreateLaptopsRequest_Options_{
+			Options: &pb.CreateLaptopsRequest_Options{AllOrNothing: true},
+		}}))
+		for _, laptop := range laptops {
+			require.NoError(t, stream.Send(&pb.CreateLaptopsRequest{Data: &pb.CreateLaptopsRequest_Laptop{Laptop: laptop}}))
+		}
+		require.NoError(t, stream.CloseSend())
+
+		results := []*pb.CreateLaptopsResponse{}
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				return results
+			}
+			require.NoError(t, err)
+			results = append(results, res)
+		}
+	}
+
+	laptops := []*pb.Laptop{sample.NewLaptop(), sample.NewLaptop()}
+
+	results := createLaptops(append(laptops, laptops[0]))
+	require.Len(t, results, 3)
+	require.Equal(t, uint32(codes.Aborted), results[0].GetCode())
+	require.Equal(t, uint32(codes.Aborted), results[1].GetCode())
+	require.Equal(t, uint32(codes.AlreadyExists), results[2].GetCode())
+
+	for _, laptop := range laptops {
+		found, err := laptopStore.Find(laptop.Id)
+		require.NoError(t, err)
+		require.Nil(t, found)
+	}
+
+	results = createLaptops(laptops)
+	require.Len(t, results, 2)
+	for i, res := range results {
+		require.Zero(t, res.GetCode(), res.GetMessage())
+		require.Equal(t, laptops[i].Id, res.GetId())
+		require.Equal(t, uint64(1), res.GetVersion())
+
+		found, err := laptopStore.Find(laptops[i].Id)
+		require.NoError(t, err)
+		requireSameLaptop(t, laptops[i], found)
+	}
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"demo-grpc/pb"
+	"math"
+	"sort"
+
+	"github.com/google/btree"
+)
+
+const indexDegree = 32
+
+//idItem orders laptop IDs in a btree
+type idItem string
+
+func (item idItem) Less(than btree.Item) bool {
+	return item < than.(idItem)
+}
+
+//indexEntry orders laptop IDs by the value of an indexed field, ties are ordered by ID
+type indexEntry struct {
+	value float64
+	id    string
+}
+
+func (entry indexEntry) Less(than btree.Item) bool {
+	other := than.(indexEntry)
+	if entry.value != other.value {
+		return entry.value < other.value
+	}
+	return entry.id < other.id
+}
+
+//sortedIndex keeps laptop IDs ordered by the value of one numeric field
+type sortedIndex struct {
+	name string
+	key  func(laptop *pb.Laptop) float64
+	tree *btree.BTree
+}
+
+func newSortedIndex(name string, key func(laptop *pb.Laptop) float64) *sortedIndex {
+	return &sortedIndex{
+		name: name,
+		key:  key,
+		tree: btree.New(indexDegree),
+	}
+}
+
+func (index *sortedIndex) insert(laptop *pb.Laptop) {
+	index.tree.ReplaceOrInsert(indexEntry{value: index.key(laptop), id: laptop.GetId()})
+}
+
+func (index *sortedIndex) remove(laptop *pb.Laptop) {
+	index.tree.Delete(indexEntry{value: index.key(laptop), id: laptop.GetId()})
+}
+
+//ascend visits the IDs whose value is between min and max, both included, until visit returns false
+func (index *sortedIndex) ascend(min, max float64, visit func(id string) bool) {
+	index.tree.AscendGreaterOrEqual(indexEntry{value: min}, func(item btree.Item) bool {
+		entry := item.(indexEntry)
+		if entry.value > max {
+			return false
+		}
+		return visit(entry.id)
+	})
+}
+
+//count returns the number of IDs whose value is between min and max, but stops counting at limit
+func (index *sortedIndex) count(min, max float64, limit int) int {
+	n := 0
+	index.ascend(min, max, func(id string) bool {
+		n++
+		return n < limit
+	})
+	return n
+}
+
+//laptopIndexes are the secondary indexes of a laptop store
+type laptopIndexes struct {
+	price    *sortedIndex
+	cpuCores *sortedIndex
+	cpuGhz   *sortedIndex
+	ram      *sortedIndex
+}
+
+func newLaptopIndexes() *laptopIndexes {
+	return &laptopIndexes{
+		price: newSortedIndex("price", func(laptop *pb.Laptop) float64 {
+			return laptop.GetPriceUsd()
+		}),
+		cpuCores: newSortedIndex("cpu_cores", func(laptop *pb.Laptop) float64 {
+			return float64(laptop.GetCpu().GetNumberCores())
+		}),
+		cpuGhz: newSortedIndex("cpu_ghz", func(laptop *pb.Laptop) float64 {
+			return laptop.GetCpu().GetMinGhz()
+		}),
+		ram: newSortedIndex("ram", func(laptop *pb.Laptop) float64 {
+			return float64(toBit(laptop.GetRam()))
+		}),
+	}
+}
+
+func (indexes *laptopIndexes) all() []*sortedIndex {
+	return []*sortedIndex{indexes.price, indexes.cpuCores, indexes.cpuGhz, indexes.ram}
+}
+
+func (indexes *laptopIndexes) insert(laptop *pb.Laptop) {
+	for _, index := range indexes.all() {
+		index.insert(laptop)
+	}
+}
+
+func (indexes *laptopIndexes) remove(laptop *pb.Laptop) {
+	for _, index := range indexes.all() {
+		index.remove(laptop)
+	}
+}
+
+//searchPlan tells which laptops a search has to check, a plan without index scans every laptop by ID
+type searchPlan struct {
+	index    *sortedIndex
+	min, max float64
+	//matches, if not nil, are the only laptops to check
+	matches map[string]float64
+}
+
+func (plan searchPlan) ascend(ids *btree.BTree, visit func(id string) bool) {
+	if plan.matches != nil {
+		matches := make([]string, 0, len(plan.matches))
+		for id := range plan.matches {
+			matches = append(matches, id)
+		}
+		sort.Strings(matches)
+
+		for _, id := range matches {
+			if !visit(id) {
+				return
+			}
+		}
+		return
+	}
+
+	if plan.index == nil {
+		ids.Ascend(func(item btree.Item) bool {
+			return visit(string(item.(idItem)))
+		})
+		return
+	}
+
+	plan.index.ascend(plan.min, plan.max, visit)
+}
+
+//plan picks the index that leaves the fewest laptops to check for the filter, out of total laptops.
+//An index range only narrows down the candidates, they are still matched against the whole query
+func (indexes *laptopIndexes) plan(filter *pb.Filter, total int) searchPlan {
+	candidates := []searchPlan{}
+
+	minPrice, maxPrice := math.Inf(-1), math.Inf(1)
+	if filter.GetMinPriceUsd() > 0 {
+		minPrice = filter.GetMinPriceUsd()
+	}
+	if filter.GetMaxPriceUsd() > 0 {
+		maxPrice = filter.GetMaxPriceUsd()
+	}
+	if !math.IsInf(minPrice, -1) || !math.IsInf(maxPrice, 1) {
+		candidates = append(candidates, searchPlan{index: indexes.price, min: minPrice, max: maxPrice})
+	}
+	if filter.GetMinCpuCores() > 0 {
+		candidates = append(candidates, searchPlan{index: indexes.cpuCores, min: float64(filter.GetMinCpuCores()), max: math.Inf(1)})
+	}
+	if filter.GetMinCpuGhz() > 0 {
+		candidates = append(candidates, searchPlan{index: indexes.cpuGhz, min: filter.GetMinCpuGhz(), max: math.Inf(1)})
+	}
+	if bits := toBit(filter.GetMinRam()); bits > 0 {
+		candidates = append(candidates, searchPlan{index: indexes.ram, min: float64(bits), max: math.Inf(1)})
+	}
+
+	best, bestCount := searchPlan{}, total
+	for _, candidate := range candidates {
+		count := candidate.index.count(candidate.min, candidate.max, bestCount)
+		if count < bestCount {
+			best, bestCount = candidate, count
+		}
+	}
+
+	return best
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"hash/fnv"
+	"sort"
+	"sync"
+)
+
+//laptopLockStripes is the number of locks the laptop IDs are spread over, laptops sharing a lock wait for each other
+const laptopLockStripes = 64
+
+//laptopLocks serializes the writes to a laptop together with the revisions they record,
+//so that the history of a laptop is in the same order as its versions
+type laptopLocks struct {
+	stripes [laptopLockStripes]sync.Mutex
+}
+
+//lock locks the laptops with the given IDs until the returned func is called.
+//The stripes are always locked in ascending order, so that two calls cannot deadlock
+func (locks *laptopLocks) lock(ids ...string) func() {
+	taken := make(map[int]bool, len(ids))
+	stripes := make([]int, 0, len(ids))
+	for _, id := range ids {
+		hash := fnv.New32a()
+		hash.Write([]byte(id))
+		stripe := int(hash.Sum32() % laptopLockStripes)
+		if !taken[stripe] {
+			taken[stripe] = true
+			stripes = append(stripes, stripe)
+		}
+	}
+	sort.Ints(stripes)
+
+	for _, stripe := range stripes {
+		locks.stripes[stripe].Lock()
+	}
+
+	return func() {
+		for i := len(stripes) - 1; i >= 0; i-- {
+			locks.stripes[stripes[i]].Unlock()
+		}
+	}
+}
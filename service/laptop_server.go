@@ -1,42 +1,85 @@
 package service
 
 import (
-	"bytes"
 	"context"
 	"demo-grpc/pb"
 	"errors"
 	"io"
 	"log"
+	"strconv"
+	"time"
 
 	//"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
-const maxImageSize = 1 << 20
+//imageChunkSize is the size of the chunks an image is downloaded in
+const imageChunkSize = 64 << 10
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 1000
+)
+
+const (
+	//createChunkSize is the number of laptops a batch create saves at a time
+	createChunkSize = 500
+	//maxAllOrNothingLaptops is the number of laptops an all-or-nothing batch create holds at most
+	maxAllOrNothingLaptops = 20000
+)
 
 //LaptopServer is the server struct which provides laptop services
 type LaptopServer struct {
-	laptopStore LaptopStore
-	imageStore  ImageStore
-	ratingStore RatingStore
+	laptopStore   LaptopStore
+	imageStore    ImageStore
+	ratingStore   RatingStore
+	revisionStore RevisionStore
+	trashStore    TrashStore
+	uploadStore   UploadStore
+
+	imageSizeLimits  ImageSizeLimits
+	variantGenerator *VariantGenerator
+	laptopLocks      laptopLocks
 }
 
-//NewLaptopServer returns a new laptop server
+//NewLaptopServer returns a new laptop server.
+//The history of the laptops is only kept if revisionStore is not nil, deletes are only soft if trashStore is not nil,
+//and images can only be uploaded in resumable sessions if uploadStore is not nil
 func NewLaptopServer(
 	laptopStore LaptopStore,
 	imageStore ImageStore,
 	ratingStore RatingStore,
+	revisionStore RevisionStore,
+	trashStore TrashStore,
+	uploadStore UploadStore,
 ) *LaptopServer {
 	return &LaptopServer{
-		laptopStore: laptopStore,
-		imageStore:  imageStore,
-		ratingStore: ratingStore,
+		laptopStore:   laptopStore,
+		imageStore:    imageStore,
+		ratingStore:   ratingStore,
+		revisionStore: revisionStore,
+		trashStore:    trashStore,
+		uploadStore:   uploadStore,
+
+		imageSizeLimits: DefaultImageSizeLimits(),
 	}
 }
 
+//SetVariantGenerator sets the generator the uploaded images are queued to for their variants, it must be called before serving.
+//Without one, the images have no variants
+func (server *LaptopServer) SetVariantGenerator(generator *VariantGenerator) {
+	server.variantGenerator = generator
+}
+
+//SetImageSizeLimits sets the largest sizes of the images that can be uploaded, it must be called before serving
+func (server *LaptopServer) SetImageSizeLimits(limits ImageSizeLimits) {
+	server.imageSizeLimits = limits
+}
+
 //CreateLaptop is a unary RPC to create a new laptop
 func (server *LaptopServer) CreateLaptop(
 	ctx context.Context,
@@ -46,17 +89,9 @@ func (server *LaptopServer) CreateLaptop(
 	laptop := req.GetLaptop()
 	log.Printf("Receive a create laptop request with id: %s", laptop.Id)
 
-	if len(laptop.Id) > 0 {
-		_, err := uuid.Parse(laptop.Id)
-		if err != nil {
-			return nil, status.Errorf(codes.InvalidArgument, "Laptop ID is not a valid UUID: %v", err)
-		}
-	} else {
-		id, err := uuid.NewRandom()
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "Cannot generate a new laptop ID: %v", err)
-		}
-		laptop.Id = id.String()
+	err := server.prepareNewLaptop(laptop)
+	if err != nil {
+		return nil, err
 	}
 
 	//if ctx.Err().Error() == "context canceled" {
@@ -69,183 +104,1488 @@ func (server *LaptopServer) CreateLaptop(
 	//	return nil, status.Error(codes.DeadlineExceeded, "Deadline is exceeded")
 	//}
 
-	err := server.laptopStore.Save(laptop)
+	version, err := server.saveNewLaptop(ctx, laptop)
 	if err != nil {
-		code := codes.Internal
-		if errors.Is(err, ErrAlreadyExists) {
-			code = codes.AlreadyExists
-		}
-
-		return nil, status.Errorf(code, "Cannot save laptop to the store: %v", err)
+		return nil, logError(err)
 	}
 
 	log.Printf("Saved laptop with id: %s", laptop.Id)
 
 	res := &pb.CreateLaptopResponse{
-		Id: laptop.Id,
+		Id:      laptop.Id,
+		Version: version,
 	}
 
 	return res, nil
 }
 
-//SearchLaptop is a server-streaming RPC to search for laptop
-func (server *LaptopServer) SearchLaptop(
-	req *pb.SearchLaptopRequest,
-	stream pb.LaptopService_SearchLaptopServer,
-) error {
-
-	filter := req.GetFilter()
-	log.Printf("Received a search laptop request with filter: %v", filter)
+//prepareNewLaptop checks the ID of a laptop about to be created, or gives it a new one if it has none
+func (server *LaptopServer) prepareNewLaptop(laptop *pb.Laptop) error {
+	if laptop == nil {
+		return status.Errorf(codes.InvalidArgument, "Laptop is missing")
+	}
 
-	err := server.laptopStore.Search(stream.Context(), filter, func(laptop *pb.Laptop) error {
-		res := &pb.SearchLaptopResponse{
-			Laptop: laptop,
+	if len(laptop.Id) > 0 {
+		_, err := uuid.Parse(laptop.Id)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "Laptop ID is not a valid UUID: %v", err)
 		}
-
-		err := stream.Send(res)
+	} else {
+		id, err := uuid.NewRandom()
 		if err != nil {
-			return err
+			return status.Errorf(codes.Internal, "Cannot generate a new laptop ID: %v", err)
 		}
+		laptop.Id = id.String()
+	}
 
-		log.Printf("Sent laptop with ID: %s", laptop.GetId())
+	return nil
+}
+
+//checkNotTrashed returns a status error if a laptop about to be created is in the trash.
+//A laptop in the trash keeps its ID, so that it can be restored, and no other laptop can take it meanwhile.
+//It must be called with the laptop locked, so that the laptop cannot be trashed before it is created
+func (server *LaptopServer) checkNotTrashed(laptopID string) error {
+	if server.trashStore == nil {
 		return nil
-	})
+	}
 
+	trashed, err := server.trashStore.Find(laptopID)
 	if err != nil {
-		return status.Errorf(codes.Internal, "Unexpected error: %v", err)
+		return status.Errorf(codes.Internal, "Cannot find laptop in the trash: %v", err)
+	}
+	if trashed != nil {
+		return status.Errorf(codes.AlreadyExists, "Laptop %s is in the trash", laptopID)
 	}
-
 	return nil
 }
 
-//UploadImage is a client-streaming RPC to upload a laptop image
-func (server *LaptopServer) UploadImage(stream pb.LaptopService_UploadImageServer) error {
+//saveNewLaptop saves a laptop checked by prepareNewLaptop, records its first revision and returns its version
+func (server *LaptopServer) saveNewLaptop(ctx context.Context, laptop *pb.Laptop) (uint64, error) {
+	unlock := server.laptopLocks.lock(laptop.Id)
+	defer unlock()
 
-	req, err := stream.Recv()
+	err := server.checkNotTrashed(laptop.Id)
 	if err != nil {
-		logError(err)
+		return 0, err
 	}
 
-	laptopID := req.GetInfo().GetLaptopId()
-	imageType := req.GetInfo().GetImageType()
-	log.Printf("Received an upload-image request for laptop %s with image type %s", laptopID, imageType)
-
-	laptop, err := server.laptopStore.Find(laptopID)
+	err = server.laptopStore.Save(laptop)
 	if err != nil {
-		return logError(status.Errorf(codes.Internal, "Cannot find laptop: %v", err))
+		return 0, status.Errorf(storeErrorCode(err), "Cannot save laptop to the store: %v", err)
 	}
 
-	if laptop == nil {
-		return logError(status.Errorf(codes.InvalidArgument, "Laptop %s doesn't exist", laptopID))
+	version := savedVersion(server.laptopStore, laptop.Id)
+	server.recordRevision(ctx, pb.LaptopRevision_CREATE, laptop, version)
+	return version, nil
+}
+
+//savedVersion returns the version of a laptop that was just saved to store. It is the first version,
+//unless the laptop took the ID of a deleted one. The laptop must be locked, so that it cannot change meanwhile.
+//The laptop is already saved, so a version that cannot be read is only logged and returned as zero
+func savedVersion(store LaptopStore, laptopID string) uint64 {
+	_, version, err := store.FindWithVersion(laptopID)
+	if err != nil {
+		log.Printf("Laptop %s is saved but its version cannot be read: %v", laptopID, err)
 	}
+	return version
+}
 
-	imageData := bytes.Buffer{}
-	imageSize := 0
+//CreateLaptops is a bidirectional-streaming RPC to create many laptops, with one response per laptop in the order
+//they are received. The first message may set the options. Laptops are saved in chunks as they arrive,
+//and one that cannot be saved does not stop the others, unless the batch is all-or-nothing.
+//An all-or-nothing batch is held until the stream ends, then saved in a single transaction,
+//which fails with FailedPrecondition if the store cannot do that
+func (server *LaptopServer) CreateLaptops(stream pb.LaptopService_CreateLaptopsServer) error {
+
+	log.Print("Receive a create laptops request")
+
+	var saver LaptopBatchSaver
+	allOrNothing := false
+	laptops := []*pb.Laptop{}
+	received := 0
 
 	for {
 		if err := contextError(stream.Context()); err != nil {
 			return err
 		}
-		log.Print("waiting to receive more data")
 
 		req, err := stream.Recv()
 		if err == io.EOF {
-			log.Print("No more data")
 			break
 		}
 		if err != nil {
-			return logError(status.Errorf(codes.Unknown, "Couldn't receive chunk data: %v", err))
+			return logError(status.Errorf(codes.Unknown, "Cannot receive stream request: %v", err))
 		}
 
-		chunk := req.GetChunkData()
-		size := len(chunk)
-		imageSize += size
-		if imageSize > maxImageSize {
-			return logError(status.Errorf(codes.InvalidArgument, "Image is too large: %d > %d", imageSize, maxImageSize))
-		}
+		switch data := req.GetData().(type) {
+		case *pb.CreateLaptopsRequest_Options_:
+			if received > 0 {
+				return logError(status.Errorf(codes.InvalidArgument, "Options must be sent before the laptops"))
+			}
 
-		_, err = imageData.Write(chunk)
-		if err != nil {
-			return logError(status.Errorf(codes.Internal, "Cannot write chunk data: %v", err))
+			allOrNothing = data.Options.GetAllOrNothing()
+			if allOrNothing {
+				var ok bool
+				saver, ok = server.laptopStore.(LaptopBatchSaver)
+				if !ok {
+					return logError(status.Errorf(codes.FailedPrecondition, "Laptop store cannot save laptops in a single transaction"))
+				}
+			}
+		case *pb.CreateLaptopsRequest_Laptop:
+			received++
+			laptops = append(laptops, data.Laptop)
+
+			if allOrNothing && received > maxAllOrNothingLaptops {
+				return logError(status.Errorf(codes.ResourceExhausted, "An all-or-nothing batch holds at most %d laptops", maxAllOrNothingLaptops))
+			}
+
+			if !allOrNothing && len(laptops) == createChunkSize {
+				err = server.createChunk(stream, received-len(laptops), laptops)
+				if err != nil {
+					return err
+				}
+				laptops = laptops[:0]
+			}
 		}
 	}
 
-	imageID, err := server.imageStore.Save(laptopID, imageType, imageData)
-	if err != nil {
-		return logError(status.Errorf(codes.Internal, "Cannot write chunk data: %v", err))
+	if allOrNothing {
+		return server.createAll(stream, saver, laptops)
 	}
+	return server.createChunk(stream, received-len(laptops), laptops)
+}
 
-	res := &pb.UploadImageResponse{
-		Id:   imageID,
-		Size: uint32(imageSize),
-	}
+//createChunk saves the laptops one by one and sends the result of each, first is the index of the first laptop
+func (server *LaptopServer) createChunk(stream pb.LaptopService_CreateLaptopsServer, first int, laptops []*pb.Laptop) error {
+	for i, laptop := range laptops {
+		res := &pb.CreateLaptopsResponse{Index: uint32(first + i)}
 
-	err = stream.SendAndClose(res)
-	if err != nil {
-		return logError(status.Errorf(codes.Unknown, "Cannot save image in the store: %v", err))
+		var version uint64
+		err := server.prepareNewLaptop(laptop)
+		if err == nil {
+			version, err = server.saveNewLaptop(stream.Context(), laptop)
+		}
+
+		res.Id = laptop.GetId()
+		if err != nil {
+			st := status.Convert(err)
+			res.Code = uint32(st.Code())
+			res.Message = st.Message()
+		} else {
+			res.Version = version
+		}
+
+		err = stream.Send(res)
+		if err != nil {
+			return logError(status.Errorf(codes.Unknown, "Cannot send stream response: %v", err))
+		}
 	}
 
-	log.Printf("Image with id: %s and size: %d", imageID, imageSize)
+	log.Printf("Created a chunk of %d laptops", len(laptops))
 	return nil
 }
 
-//RateLaptop is a bi-directional RPC that allows client to rate a stream of laptops with a score, and returns a stream of average score for each of them
-func (server *LaptopServer) RateLaptop(stream pb.LaptopService_RateLaptopServer) error {
+//createAll saves every laptop in a single transaction, or none of them if any is invalid or cannot be saved
+func (server *LaptopServer) createAll(stream pb.LaptopService_CreateLaptopsServer, saver LaptopBatchSaver, laptops []*pb.Laptop) error {
+	results := make([]*pb.CreateLaptopsResponse, len(laptops))
+	ids := make(map[string]bool, len(laptops))
+	valid := true
 
-	for {
-		err := contextError(stream.Context())
+	for i, laptop := range laptops {
+		results[i] = &pb.CreateLaptopsResponse{Index: uint32(i)}
+
+		err := server.prepareNewLaptop(laptop)
+		if err == nil && ids[laptop.Id] {
+			err = status.Errorf(codes.AlreadyExists, "Laptop %s is in the batch more than once", laptop.Id)
+		}
 		if err != nil {
-			return err
+			st := status.Convert(err)
+			results[i].Code = uint32(st.Code())
+			results[i].Message = st.Message()
+			valid = false
 		}
 
-		req, err := stream.Recv()
-		if err == io.EOF {
-			log.Print("No more data")
-			break
+		results[i].Id = laptop.GetId()
+		ids[laptop.GetId()] = true
+	}
+
+	var saveErr error
+	if valid {
+		saveErr = server.saveAll(stream.Context(), saver, laptops, results)
+	} else {
+		saveErr = status.Errorf(codes.Aborted, "No laptop is saved because some laptops of the batch are invalid")
+	}
+
+	for i := range laptops {
+		if saveErr != nil && results[i].Code == 0 {
+			st := status.Convert(saveErr)
+			results[i].Code = uint32(st.Code())
+			results[i].Message = st.Message()
 		}
+
+		err := stream.Send(results[i])
 		if err != nil {
-			return logError(status.Errorf(codes.Unknown, "Cannot receive stream request: %v", err))
+			return logError(status.Errorf(codes.Unknown, "Cannot send stream response: %v", err))
 		}
+	}
 
-		laptopID := req.GetLaptopId()
-		score := req.GetScore()
+	log.Printf("Created an all-or-nothing batch of %d laptops: %v", len(laptops), saveErr == nil)
+	return nil
+}
 
-		log.Printf("Received a rate-laptop request: id=%s, score=%.2f", laptopID, score)
+//saveAll saves laptops checked by prepareNewLaptop in a single transaction and records their first revisions.
+//If any laptop is in the trash, none is saved and the result of that laptop gets the error.
+//The laptops are locked until the revisions are recorded, not while the results are sent
+func (server *LaptopServer) saveAll(
+	ctx context.Context,
+	saver LaptopBatchSaver,
+	laptops []*pb.Laptop,
+	results []*pb.CreateLaptopsResponse,
+) error {
+	ids := make([]string, len(laptops))
+	for i, laptop := range laptops {
+		ids[i] = laptop.GetId()
+	}
 
-		found, err := server.laptopStore.Find(laptopID)
+	unlock := server.laptopLocks.lock(ids...)
+	defer unlock()
+
+	trashed := false
+	for i, laptop := range laptops {
+		err := server.checkNotTrashed(laptop.GetId())
 		if err != nil {
-			return logError(status.Errorf(codes.Unknown, "Cannot find laptop: %v", err))
+			st := status.Convert(err)
+			results[i].Code = uint32(st.Code())
+			results[i].Message = st.Message()
+			trashed = true
 		}
+	}
+	if trashed {
+		return status.Errorf(codes.Aborted, "No laptop is saved because some laptops of the batch are invalid")
+	}
 
-		if found == nil {
-			return logError(status.Errorf(codes.NotFound, "LaptopID %s is not found", laptopID))
+	err := saver.SaveAll(laptops)
+	if err != nil {
+		return status.Errorf(storeErrorCode(err), "Cannot save laptops to the store: %v", err)
+	}
+
+	for i, laptop := range laptops {
+		results[i].Version = savedVersion(server.laptopStore, laptop.Id)
+		server.recordRevision(ctx, pb.LaptopRevision_CREATE, laptop, results[i].Version)
+	}
+	return nil
+}
+
+//GetLaptop is a unary RPC to fetch a laptop by ID
+func (server *LaptopServer) GetLaptop(
+	ctx context.Context,
+	req *pb.GetLaptopRequest,
+) (*pb.GetLaptopResponse, error) {
+
+	laptopID := req.GetId()
+	log.Printf("Receive a get laptop request with id: %s", laptopID)
+
+	laptop, version, err := server.laptopStore.FindWithVersion(laptopID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Cannot find laptop: %v", err)
+	}
+
+	if laptop == nil {
+		return nil, status.Errorf(codes.NotFound, "Laptop %s is not found", laptopID)
+	}
+
+	res := &pb.GetLaptopResponse{
+		Laptop:  laptop,
+		Version: version,
+	}
+
+	return res, nil
+}
+
+//UpdateLaptop is a unary RPC to replace an existing laptop, or only the fields of its update mask.
+//The update fails with Aborted if an expected version is given and the stored laptop has moved on
+func (server *LaptopServer) UpdateLaptop(
+	ctx context.Context,
+	req *pb.UpdateLaptopRequest,
+) (*pb.UpdateLaptopResponse, error) {
+
+	laptop := req.GetLaptop()
+	log.Printf("Receive an update laptop request with id: %s", laptop.GetId())
+
+	_, err := uuid.Parse(laptop.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Laptop ID is not a valid UUID: %v", err)
+	}
+
+	expectedVersion := req.GetExpectedVersion()
+
+	unlock := server.laptopLocks.lock(laptop.GetId())
+	defer unlock()
+
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) > 0 {
+		err = validateFieldMask(laptop, paths)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
 		}
 
-		rating, err := server.ratingStore.Add(laptopID, score)
+		existing, version, err := server.laptopStore.FindWithVersion(laptop.GetId())
 		if err != nil {
-			return logError(status.Errorf(codes.Internal, "Cannot add rating to the store: %v", err))
+			return nil, status.Errorf(codes.Internal, "Cannot find laptop: %v", err)
 		}
 
-		res := &pb.RateLaptopResponse{
-			LaptopId:     laptopID,
-			RatedCount:   rating.Count,
-			AverageScore: rating.Sum / float64(rating.Count),
+		if existing == nil {
+			return nil, status.Errorf(codes.NotFound, "Laptop %s is not found", laptop.GetId())
 		}
 
-		err = stream.Send(res)
+		if expectedVersion != 0 && expectedVersion != version {
+			return nil, status.Errorf(codes.Aborted, "Laptop %s is at version %d, not %d", laptop.GetId(), version, expectedVersion)
+		}
+
+		//the mask is applied to the version we just read, so the store must still hold it when we write back
+		expectedVersion = version
+
+		err = applyFieldMask(existing, laptop, paths)
 		if err != nil {
-			return logError(status.Errorf(codes.Unknown, "Cannot send stream response: %v", err))
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+
+		laptop = existing
+	}
+
+	laptop.UpdatedAt = ptypes.TimestampNow()
+
+	version, err := server.laptopStore.Update(laptop, expectedVersion)
+	if err != nil {
+		return nil, status.Errorf(storeErrorCode(err), "Cannot update laptop in the store: %v", err)
+	}
+
+	log.Printf("Updated laptop with id: %s to version %d", laptop.Id, version)
+
+	server.recordRevision(ctx, pb.LaptopRevision_UPDATE, laptop, version)
+
+	res := &pb.UpdateLaptopResponse{
+		Laptop:  laptop,
+		Version: version,
+	}
+
+	return res, nil
+}
+
+//DeleteLaptop is a unary RPC to remove a laptop by ID.
+//If the server has a trash, the laptop is moved there and can be restored until it is purged
+func (server *LaptopServer) DeleteLaptop(
+	ctx context.Context,
+	req *pb.DeleteLaptopRequest,
+) (*pb.DeleteLaptopResponse, error) {
+
+	laptopID := req.GetId()
+	log.Printf("Receive a delete laptop request with id: %s", laptopID)
+
+	unlock := server.laptopLocks.lock(laptopID)
+	defer unlock()
+
+	//the history and the trash keep the laptop as it was last seen before it was deleted
+	laptop, version, err := server.laptopStore.FindWithVersion(laptopID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Cannot find laptop: %v", err)
+	}
+
+	if server.trashStore != nil {
+		err = server.trashLaptop(ctx, laptop, version, req.GetExpectedVersion())
+	} else {
+		err = server.laptopStore.Delete(laptopID, req.GetExpectedVersion())
+	}
+	if err != nil {
+		return nil, status.Errorf(storeErrorCode(err), "Cannot delete laptop from the store: %v", err)
+	}
+
+	log.Printf("Deleted laptop with id: %s", laptopID)
+
+	if laptop == nil {
+		laptop = &pb.Laptop{Id: laptopID}
+	}
+	server.recordRevision(ctx, pb.LaptopRevision_DELETE, laptop, 0)
+
+	res := &pb.DeleteLaptopResponse{
+		Id: laptopID,
+	}
+
+	return res, nil
+}
+
+//trashLaptop moves a laptop from the store to the trash.
+//The laptop is only deleted at the version it was read, so that the trash never holds an outdated copy
+func (server *LaptopServer) trashLaptop(ctx context.Context, laptop *pb.Laptop, version uint64, expectedVersion uint64) error {
+	if laptop == nil {
+		return ErrNotFound
+	}
+
+	if expectedVersion != 0 && expectedVersion != version {
+		return ErrVersionMismatch
+	}
+
+	deletedAt, err := ptypes.TimestampProto(time.Now())
+	if err != nil {
+		return err
+	}
+
+	err = server.trashStore.Put(&pb.TrashedLaptop{
+		Laptop:    laptop,
+		Version:   version,
+		DeletedBy: callerName(ctx),
+		DeletedAt: deletedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = server.laptopStore.Delete(laptop.GetId(), version)
+	if err != nil {
+		if err := server.trashStore.Remove(laptop.GetId()); err != nil {
+			log.Printf("Cannot take laptop %s back out of the trash: %v", laptop.GetId(), err)
 		}
+		return err
 	}
 
 	return nil
 }
 
-func logError(err error) error {
+//ListTrashedLaptops is a unary RPC to page through the laptops in the trash in ascending ID order
+func (server *LaptopServer) ListTrashedLaptops(
+	ctx context.Context,
+	req *pb.ListTrashedLaptopsRequest,
+) (*pb.ListTrashedLaptopsResponse, error) {
+
+	log.Printf("Receive a list trashed laptops request with page size: %d", req.GetPageSize())
+
+	if server.trashStore == nil {
+		return nil, status.Errorf(codes.Unimplemented, "Deleted laptops are not kept")
+	}
+
+	afterID, err := decodePageToken(req.GetPageToken())
 	if err != nil {
-		log.Print(err)
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	pageSize := int(req.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	//fetch one more laptop than asked for, to find out if there is a next page
+	laptops, err := server.trashStore.List(afterID, pageSize+1)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Cannot list trashed laptops: %v", err)
+	}
+
+	res := &pb.ListTrashedLaptopsResponse{}
+	if len(laptops) > pageSize {
+		laptops = laptops[:pageSize]
+		res.NextPageToken = encodePageToken(laptops[pageSize-1].GetLaptop().GetId())
+	}
+	res.Laptops = laptops
+
+	return res, nil
+}
+
+//RestoreTrashedLaptop is a unary RPC to take a laptop out of the trash and save it again.
+//The restored laptop gets the version after the one it was deleted at
+func (server *LaptopServer) RestoreTrashedLaptop(
+	ctx context.Context,
+	req *pb.RestoreTrashedLaptopRequest,
+) (*pb.RestoreTrashedLaptopResponse, error) {
+
+	laptopID := req.GetId()
+	log.Printf("Receive a restore trashed laptop request with id: %s", laptopID)
+
+	if server.trashStore == nil {
+		return nil, status.Errorf(codes.Unimplemented, "Deleted laptops are not kept")
+	}
+
+	unlock := server.laptopLocks.lock(laptopID)
+	defer unlock()
+
+	trashed, err := server.trashStore.Find(laptopID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Cannot find laptop in the trash: %v", err)
+	}
+	if trashed == nil {
+		return nil, status.Errorf(codes.NotFound, "Laptop %s is not in the trash", laptopID)
+	}
+
+	//taking the laptop out of the trash first means a concurrent purge either wins or finds nothing to purge
+	err = server.trashStore.Remove(laptopID)
+	if err != nil {
+		return nil, status.Errorf(storeErrorCode(err), "Cannot take laptop out of the trash: %v", err)
+	}
+
+	laptop := trashed.GetLaptop()
+	err = server.laptopStore.Save(laptop)
+	if err != nil {
+		if err := server.trashStore.Put(trashed); err != nil {
+			log.Printf("Cannot put laptop %s back in the trash: %v", laptopID, err)
+		}
+		return nil, status.Errorf(storeErrorCode(err), "Cannot save laptop to the store: %v", err)
+	}
+
+	log.Printf("Restored laptop with id: %s from the trash", laptopID)
+
+	version := savedVersion(server.laptopStore, laptopID)
+	server.recordRevision(ctx, pb.LaptopRevision_RESTORE, laptop, version)
+
+	res := &pb.RestoreTrashedLaptopResponse{
+		Laptop:  laptop,
+		Version: version,
+	}
+
+	return res, nil
+}
+
+//ListLaptops is a unary RPC to page through all laptops in ascending ID order.
+//Page tokens are keyed on the last returned ID, so they stay valid while laptops are created or deleted
+func (server *LaptopServer) ListLaptops(
+	ctx context.Context,
+	req *pb.ListLaptopsRequest,
+) (*pb.ListLaptopsResponse, error) {
+
+	log.Printf("Receive a list laptops request with page size: %d", req.GetPageSize())
+
+	afterID, err := decodePageToken(req.GetPageToken())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	pageSize := int(req.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	//fetch one more laptop than asked for, to find out if there is a next page
+	laptops, err := server.laptopStore.List(ctx, afterID, pageSize+1)
+	if err != nil {
+		if err := contextError(ctx); err != nil {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "Cannot list laptops: %v", err)
+	}
+
+	res := &pb.ListLaptopsResponse{}
+	if len(laptops) > pageSize {
+		laptops = laptops[:pageSize]
+		res.NextPageToken = encodePageToken(laptops[pageSize-1].GetId())
+	}
+	res.Laptops = laptops
+
+	return res, nil
+}
+
+//SearchLaptop is a server-streaming RPC to search for laptop
+func (server *LaptopServer) SearchLaptop(
+	req *pb.SearchLaptopRequest,
+	stream pb.LaptopService_SearchLaptopServer,
+) error {
+
+	filter := req.GetFilter()
+	log.Printf("Received a search laptop request with filter: %v", filter)
+
+	options := &SearchOptions{
+		SortBy:     req.GetSortBy(),
+		Descending: req.GetDescending(),
+		MaxResults: int(req.GetMaxResults()),
+		Text:       req.GetText(),
+		Rating:     server.averageRating,
+	}
+
+	if req.GetQuery() != "" {
+		query, err := ParseQuery(req.GetQuery())
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		options.Query = query
+	}
+
+	err := server.laptopStore.Search(stream.Context(), filter, options, func(laptop *pb.Laptop) error {
+		res := &pb.SearchLaptopResponse{
+			Laptop: laptop,
+		}
+
+		err := stream.Send(res)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("Sent laptop with ID: %s", laptop.GetId())
+		return nil
+	})
+
+	if err != nil {
+		return status.Errorf(codes.Internal, "Unexpected error: %v", err)
+	}
+
+	return nil
+}
+
+//SearchFacets is a unary RPC that counts the laptops matching a filter by brand, CPU brand, RAM, screen panel and price,
+//in a single pass over the store and without sending the laptops themselves
+func (server *LaptopServer) SearchFacets(
+	ctx context.Context,
+	req *pb.SearchFacetsRequest,
+) (*pb.SearchFacetsResponse, error) {
+
+	filter := req.GetFilter()
+	log.Printf("Received a search facets request with filter: %v", filter)
+
+	options := &SearchOptions{}
+	if req.GetQuery() != "" {
+		query, err := ParseQuery(req.GetQuery())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		options.Query = query
+	}
+
+	counter := newFacetCounter(req.GetPriceBucketUsd())
+	err := server.laptopStore.Search(ctx, filter, options, func(laptop *pb.Laptop) error {
+		counter.add(laptop)
+		return nil
+	})
+
+	if err != nil {
+		if err := contextError(ctx); err != nil {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "Unexpected error: %v", err)
+	}
+
+	return counter.response(), nil
+}
+
+//WatchLaptops is a server-streaming RPC that sends the laptops matching a filter if asked to,
+//then every creation, update or deletion of a matching laptop until the client goes away.
+//A laptop updated so that it no longer matches is sent once more as a LEFT event
+func (server *LaptopServer) WatchLaptops(
+	req *pb.WatchLaptopsRequest,
+	stream pb.LaptopService_WatchLaptopsServer,
+) error {
+
+	filter := req.GetFilter()
+	log.Printf("Received a watch laptops request with filter: %v", filter)
+
+	watcher, ok := server.laptopStore.(LaptopWatcher)
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "Laptop store cannot be watched")
+	}
+
+	err := watcher.Watch(stream.Context(), filter, req.GetSendInitial(), func(event *pb.LaptopEvent) error {
+		res := &pb.WatchLaptopsResponse{
+			Event: event,
+		}
+
+		err := stream.Send(res)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("Sent %s event of laptop with ID: %s", event.GetType(), event.GetLaptop().GetId())
+		return nil
+	})
+
+	if err != nil {
+		if err := contextError(stream.Context()); err != nil {
+			return err
+		}
+		if errors.Is(err, ErrWatcherTooSlow) {
+			return status.Errorf(codes.ResourceExhausted, "%v", err)
+		}
+		return status.Errorf(codes.Internal, "Unexpected error: %v", err)
+	}
+
+	return nil
+}
+
+//UploadImage is a client-streaming RPC to upload a laptop image, the chunks are streamed to the image store as they arrive
+func (server *LaptopServer) UploadImage(stream pb.LaptopService_UploadImageServer) error {
+
+	req, err := stream.Recv()
+	if err != nil {
+		return logError(status.Errorf(codes.Unknown, "Cannot receive image info: %v", err))
+	}
+
+	laptopID := req.GetInfo().GetLaptopId()
+	imageType := req.GetInfo().GetImageType()
+	log.Printf("Received an upload-image request for laptop %s with image type %s", laptopID, imageType)
+
+	imageType, err = ParseImageType(imageType)
+	if err != nil {
+		return logError(status.Errorf(codes.InvalidArgument, "%v", err))
+	}
+
+	laptop, err := server.laptopStore.Find(laptopID)
+	if err != nil {
+		return logError(status.Errorf(codes.Internal, "Cannot find laptop: %v", err))
+	}
+
+	if laptop == nil {
+		return logError(status.Errorf(codes.InvalidArgument, "Laptop %s doesn't exist", laptopID))
+	}
+
+	maxSize := server.imageSizeLimits.For(imageType)
+	reader := &imageChunkReader{stream: stream}
+
+	imageID, err := SaveImage(server.imageStore, laptopID, imageType, reader, maxSize)
+	if reader.err != nil {
+		return reader.err
+	}
+	if err == ErrImageTooLarge {
+		return logError(status.Errorf(codes.InvalidArgument, "Image is too large: more than %d bytes", maxSize))
+	}
+	if err != nil {
+		return logError(status.Errorf(storeErrorCode(err), "Cannot save image in the store: %v", err))
+	}
+	imageSize := reader.size
+	server.queueVariants(imageID)
+
+	res := &pb.UploadImageResponse{
+		Id:   imageID,
+		Size: uint32(imageSize),
+	}
+
+	err = stream.SendAndClose(res)
+	if err != nil {
+		return logError(status.Errorf(codes.Unknown, "Cannot save image in the store: %v", err))
+	}
+
+	log.Printf("Image with id: %s and size: %d", imageID, imageSize)
+	return nil
+}
+
+//StartUpload is a unary RPC that starts a resumable image upload and returns its ID.
+//The data is then sent with UploadChunks, as many times as needed, and the image is saved by FinishUpload
+func (server *LaptopServer) StartUpload(ctx context.Context, req *pb.StartUploadRequest) (*pb.StartUploadResponse, error) {
+	if server.uploadStore == nil {
+		return nil, logError(status.Errorf(codes.Unimplemented, "Resumable uploads are not enabled"))
+	}
+
+	laptopID := req.GetInfo().GetLaptopId()
+	imageType := req.GetInfo().GetImageType()
+	log.Printf("Receive a start-upload request for laptop %s with image type %s", laptopID, imageType)
+
+	imageType, err := ParseImageType(imageType)
+	if err != nil {
+		return nil, logError(status.Errorf(codes.InvalidArgument, "%v", err))
+	}
+
+	laptop, err := server.laptopStore.Find(laptopID)
+	if err != nil {
+		return nil, logError(status.Errorf(codes.Internal, "Cannot find laptop: %v", err))
+	}
+	if laptop == nil {
+		return nil, logError(status.Errorf(codes.InvalidArgument, "Laptop %s doesn't exist", laptopID))
+	}
+
+	session, err := server.uploadStore.Create(laptopID, imageType)
+	if err != nil {
+		return nil, logError(status.Errorf(codes.Internal, "Cannot start upload: %v", err))
+	}
+
+	log.Printf("Started upload with id: %s", session.ID)
+	return &pb.StartUploadResponse{UploadId: session.ID}, nil
+}
+
+//UploadChunks is a client-streaming RPC that writes data to a resumable upload.
+//The first message gives the upload ID and the offset of the first chunk, which cannot be past the committed data.
+//Every chunk is committed once it is received, so the data of a stream that breaks is kept
+func (server *LaptopServer) UploadChunks(stream pb.LaptopService_UploadChunksServer) error {
+	if server.uploadStore == nil {
+		return logError(status.Errorf(codes.Unimplemented, "Resumable uploads are not enabled"))
+	}
+
+	req, err := stream.Recv()
+	if err != nil {
+		return logError(status.Errorf(codes.Unknown, "Cannot receive upload position: %v", err))
+	}
+
+	position := req.GetPosition()
+	if position == nil {
+		return logError(status.Errorf(codes.InvalidArgument, "The first message must give the upload position"))
+	}
+
+	uploadID := position.GetUploadId()
+	session, err := server.uploadStore.Find(uploadID)
+	if err != nil {
+		return logError(status.Errorf(codes.Internal, "Cannot find upload: %v", err))
+	}
+	if session == nil {
+		return logError(status.Errorf(codes.NotFound, "Upload %s is not found", uploadID))
+	}
+
+	offset := int64(position.GetOffset())
+	committed := session.Size
+	maxSize := server.imageSizeLimits.For(session.ImageType)
+
+	for {
+		if err := contextError(stream.Context()); err != nil {
+			return err
+		}
+
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return logError(status.Errorf(codes.Unknown, "Couldn't receive chunk data: %v", err))
+		}
+
+		chunk := req.GetChunkData()
+		if offset+int64(len(chunk)) > maxSize {
+			return logError(status.Errorf(codes.InvalidArgument, "Image is too large: more than %d bytes", maxSize))
+		}
+
+		committed, err = server.uploadStore.Write(uploadID, offset, chunk)
+		if err == ErrUploadGap {
+			return logError(status.Errorf(codes.OutOfRange, "Chunk offset %d is past the %d committed bytes", offset, committed))
+		}
+		if err != nil {
+			return logError(status.Errorf(storeErrorCode(err), "Cannot write chunk data: %v", err))
+		}
+		offset += int64(len(chunk))
+	}
+
+	err = stream.SendAndClose(&pb.UploadChunksResponse{CommittedSize: uint64(committed)})
+	if err != nil {
+		return logError(status.Errorf(codes.Unknown, "Cannot send response: %v", err))
+	}
+
+	log.Printf("Upload %s has %d committed bytes", uploadID, committed)
+	return nil
+}
+
+//QueryUpload is a unary RPC that returns how much data is committed to a resumable upload,
+//which is the offset the upload should be resumed from
+func (server *LaptopServer) QueryUpload(ctx context.Context, req *pb.QueryUploadRequest) (*pb.QueryUploadResponse, error) {
+	if server.uploadStore == nil {
+		return nil, logError(status.Errorf(codes.Unimplemented, "Resumable uploads are not enabled"))
+	}
+
+	session, err := server.uploadStore.Find(req.GetUploadId())
+	if err != nil {
+		return nil, logError(status.Errorf(codes.Internal, "Cannot find upload: %v", err))
+	}
+	if session == nil {
+		return nil, logError(status.Errorf(codes.NotFound, "Upload %s is not found", req.GetUploadId()))
+	}
+
+	res := &pb.QueryUploadResponse{
+		UploadId:      session.ID,
+		LaptopId:      session.LaptopID,
+		ImageType:     session.ImageType,
+		CommittedSize: uint64(session.Size),
+	}
+	return res, nil
+}
+
+//FinishUpload is a unary RPC that saves the image of a resumable upload and ends the upload.
+//The size must be the size of the whole image, so that an upload missing its last chunks is not saved
+func (server *LaptopServer) FinishUpload(ctx context.Context, req *pb.FinishUploadRequest) (*pb.UploadImageResponse, error) {
+	if server.uploadStore == nil {
+		return nil, logError(status.Errorf(codes.Unimplemented, "Resumable uploads are not enabled"))
+	}
+
+	uploadID := req.GetUploadId()
+	var imageID string
+	var size int64
+	//the upload stays locked while it is saved, so that concurrent chunks or finishes cannot change or save it again
+	err := server.uploadStore.Finish(uploadID, func(session *UploadSession, data io.Reader) error {
+		if uint64(session.Size) != req.GetSize() {
+			return status.Errorf(codes.FailedPrecondition, "Upload has %d committed bytes, not %d", session.Size, req.GetSize())
+		}
+
+		laptop, err := server.laptopStore.Find(session.LaptopID)
+		if err != nil {
+			return status.Errorf(codes.Internal, "Cannot find laptop: %v", err)
+		}
+		if laptop == nil {
+			return status.Errorf(codes.InvalidArgument, "Laptop %s doesn't exist", session.LaptopID)
+		}
+
+		maxSize := server.imageSizeLimits.For(session.ImageType)
+		imageID, err = SaveImage(server.imageStore, session.LaptopID, session.ImageType, data, maxSize)
+		if err == ErrImageTooLarge {
+			return status.Errorf(codes.InvalidArgument, "Image is too large: more than %d bytes", maxSize)
+		}
+		if err != nil {
+			return status.Errorf(storeErrorCode(err), "Cannot save image in the store: %v", err)
+		}
+
+		size = session.Size
+		return nil
+	})
+	if err == ErrNotFound {
+		return nil, logError(status.Errorf(codes.NotFound, "Upload %s is not found", uploadID))
+	}
+	if err != nil {
+		if _, ok := status.FromError(err); !ok {
+			err = status.Errorf(codes.Internal, "Cannot finish upload: %v", err)
+		}
+		return nil, logError(err)
+	}
+
+	server.queueVariants(imageID)
+
+	log.Printf("Finished upload %s as image with id: %s and size: %d", uploadID, imageID, size)
+	return &pb.UploadImageResponse{Id: imageID, Size: uint32(size)}, nil
+}
+
+//DownloadImage is a server-streaming RPC that sends the info of an image, then its data in chunks.
+//A request for a variant that is not generated gets the original image, whose info has variant 0
+func (server *LaptopServer) DownloadImage(req *pb.DownloadImageRequest, stream pb.LaptopService_DownloadImageServer) error {
+	imageID := req.GetImageId()
+	log.Printf("Receive a download-image request for image %s and variant %d", imageID, req.GetVariant())
+
+	maxSide := int(req.GetVariant())
+	if !isImageVariantSize(maxSide) {
+		return logError(status.Errorf(codes.InvalidArgument, "Variant %d is not one of %v", maxSide, ImageVariantSizes))
+	}
+
+	info, err := server.imageStore.Find(imageID)
+	if err != nil {
+		return logError(status.Errorf(codes.Internal, "Cannot find image: %v", err))
+	}
+	if info == nil {
+		return logError(status.Errorf(codes.NotFound, "Image %s is not found", imageID))
+	}
+
+	variant := info.Variant(maxSide)
+	var file io.ReadCloser
+	if variant != nil {
+		file, err = server.imageStore.OpenVariant(imageID, maxSide)
+	} else {
+		file, err = server.imageStore.Open(imageID)
+	}
+	if err == ErrNotFound {
+		return logError(status.Errorf(codes.NotFound, "Image %s is not found", imageID))
+	}
+	if err != nil {
+		return logError(status.Errorf(codes.Internal, "Cannot open image: %v", err))
+	}
+	defer file.Close()
+
+	res := &pb.DownloadImageResponse{
+		Data: &pb.DownloadImageResponse_Info{
+			Info: imageMetadata(info, variant),
+		},
+	}
+	err = stream.Send(res)
+	if err != nil {
+		return logError(status.Errorf(codes.Unknown, "Cannot send image info: %v", err))
+	}
+
+	buffer := make([]byte, imageChunkSize)
+	imageSize := 0
+
+	for {
+		if err := contextError(stream.Context()); err != nil {
+			return err
+		}
+
+		n, err := file.Read(buffer)
+		if n > 0 {
+			res := &pb.DownloadImageResponse{
+				Data: &pb.DownloadImageResponse_ChunkData{
+					ChunkData: buffer[:n],
+				},
+			}
+
+			err := stream.Send(res)
+			if err != nil {
+				return logError(status.Errorf(codes.Unknown, "Cannot send chunk data: %v", err))
+			}
+			imageSize += n
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return logError(status.Errorf(codes.Internal, "Cannot read image: %v", err))
+		}
+	}
+
+	log.Printf("Sent image with id: %s and size: %d", imageID, imageSize)
+	return nil
+}
+
+//ListImages is a unary RPC that returns the info of the images of a laptop, ordered by image ID.
+//With a variant, it returns the info of that variant of each image, or of the original image if the variant is not generated
+func (server *LaptopServer) ListImages(ctx context.Context, req *pb.ListImagesRequest) (*pb.ListImagesResponse, error) {
+	laptopID := req.GetLaptopId()
+
+	maxSide := int(req.GetVariant())
+	if !isImageVariantSize(maxSide) {
+		return nil, logError(status.Errorf(codes.InvalidArgument, "Variant %d is not one of %v", maxSide, ImageVariantSizes))
+	}
+
+	laptop, err := server.laptopStore.Find(laptopID)
+	if err != nil {
+		return nil, logError(status.Errorf(codes.Internal, "Cannot find laptop: %v", err))
+	}
+	if laptop == nil {
+		return nil, logError(status.Errorf(codes.NotFound, "Laptop %s is not found", laptopID))
+	}
+
+	images, err := server.imageStore.List(laptopID)
+	if err != nil {
+		return nil, logError(status.Errorf(codes.Internal, "Cannot list images: %v", err))
+	}
+
+	res := &pb.ListImagesResponse{
+		Images: make([]*pb.ImageMetadata, 0, len(images)),
+	}
+	for _, info := range images {
+		res.Images = append(res.Images, imageMetadata(info, info.Variant(maxSide)))
+	}
+	return res, nil
+}
+
+//imageMetadata returns the metadata of a variant of an image, or of the image itself if variant is nil
+func imageMetadata(info *ImageInfo, variant *ImageVariant) *pb.ImageMetadata {
+	if variant == nil {
+		return &pb.ImageMetadata{
+			Id:        info.ID,
+			LaptopId:  info.LaptopID,
+			ImageType: info.Type,
+			Size:      uint64(info.Size),
+			Width:     uint32(info.Width),
+			Height:    uint32(info.Height),
+		}
+	}
+
+	return &pb.ImageMetadata{
+		Id:        info.ID,
+		LaptopId:  info.LaptopID,
+		ImageType: variant.Type,
+		Size:      uint64(variant.Size),
+		Width:     uint32(variant.Width),
+		Height:    uint32(variant.Height),
+		Variant:   uint32(variant.MaxSide),
+	}
+}
+
+//queueVariants queues a new image for its variants to be generated, if the server has a generator
+func (server *LaptopServer) queueVariants(imageID string) {
+	if server.variantGenerator != nil {
+		server.variantGenerator.Enqueue(imageID)
+	}
+}
+
+//imageChunkReader reads the chunks of an upload-image stream as one stream of data.
+//An error of the stream is logged and kept in err, so that it is not reported as an error of the image store
+type imageChunkReader struct {
+	stream pb.LaptopService_UploadImageServer
+	chunk  []byte
+	size   int64
+	err    error
+}
+
+func (reader *imageChunkReader) Read(p []byte) (int, error) {
+	for len(reader.chunk) == 0 {
+		if err := contextError(reader.stream.Context()); err != nil {
+			reader.err = err
+			return 0, err
+		}
+
+		req, err := reader.stream.Recv()
+		if err == io.EOF {
+			log.Print("No more data")
+			return 0, io.EOF
+		}
+		if err != nil {
+			reader.err = logError(status.Errorf(codes.Unknown, "Couldn't receive chunk data: %v", err))
+			return 0, reader.err
+		}
+
+		reader.chunk = req.GetChunkData()
+	}
+
+	n := copy(p, reader.chunk)
+	reader.chunk = reader.chunk[n:]
+	reader.size += int64(n)
+	return n, nil
+}
+
+//RateLaptop is a bi-directional RPC that allows client to rate a stream of laptops with a score, and returns a stream of average score for each of them
+func (server *LaptopServer) RateLaptop(stream pb.LaptopService_RateLaptopServer) error {
+
+	for {
+		err := contextError(stream.Context())
+		if err != nil {
+			return err
+		}
+
+		req, err := stream.Recv()
+		if err == io.EOF {
+			log.Print("No more data")
+			break
+		}
+		if err != nil {
+			return logError(status.Errorf(codes.Unknown, "Cannot receive stream request: %v", err))
+		}
+
+		laptopID := req.GetLaptopId()
+		score := req.GetScore()
+
+		log.Printf("Received a rate-laptop request: id=%s, score=%.2f", laptopID, score)
+
+		found, err := server.laptopStore.Find(laptopID)
+		if err != nil {
+			return logError(status.Errorf(codes.Unknown, "Cannot find laptop: %v", err))
+		}
+
+		if found == nil {
+			return logError(status.Errorf(codes.NotFound, "LaptopID %s is not found", laptopID))
+		}
+
+		rating, err := server.ratingStore.Add(laptopID, score)
+		if err != nil {
+			return logError(status.Errorf(storeErrorCode(err), "Cannot add rating to the store: %v", err))
+		}
+
+		res := &pb.RateLaptopResponse{
+			LaptopId:     laptopID,
+			RatedCount:   rating.Count,
+			AverageScore: rating.Sum / float64(rating.Count),
+		}
+
+		err = stream.Send(res)
+		if err != nil {
+			return logError(status.Errorf(codes.Unknown, "Cannot send stream response: %v", err))
+		}
+	}
+
+	return nil
+}
+
+//ExportCatalog is a server-streaming RPC that sends every laptop in ascending ID order,
+//each with its rating and the info of its images
+func (server *LaptopServer) ExportCatalog(
+	req *pb.ExportCatalogRequest,
+	stream pb.LaptopService_ExportCatalogServer,
+) error {
+
+	log.Print("Received an export catalog request")
+
+	ctx := stream.Context()
+	afterID := ""
+	exported := 0
+
+	for {
+		laptops, err := server.laptopStore.List(ctx, afterID, maxPageSize)
+		if err != nil {
+			if err := contextError(ctx); err != nil {
+				return err
+			}
+			return logError(status.Errorf(codes.Internal, "Cannot list laptops: %v", err))
+		}
+
+		for _, laptop := range laptops {
+			entry, err := catalogEntry(laptop, server.ratingStore, server.imageStore)
+			if err != nil {
+				return logError(status.Errorf(codes.Internal, "%v", err))
+			}
+
+			err = stream.Send(&pb.ExportCatalogResponse{Entry: entry})
+			if err != nil {
+				return logError(status.Errorf(codes.Unknown, "Cannot send stream response: %v", err))
+			}
+			exported++
+		}
+
+		if len(laptops) < maxPageSize {
+			break
+		}
+		afterID = laptops[len(laptops)-1].GetId()
+	}
+
+	log.Printf("Exported %d laptops", exported)
+	return nil
+}
+
+//ImportCatalog is a client-streaming RPC that saves the catalog entries it receives.
+//The first message may set the mode, which decides if existing laptops are skipped or replaced.
+//An entry that cannot be imported does not stop the others, the response counts and lists the failures
+func (server *LaptopServer) ImportCatalog(stream pb.LaptopService_ImportCatalogServer) error {
+
+	log.Print("Received an import catalog request")
+
+	importer := newCatalogImporter(server.laptopStore, server.imageStore, server.ratingStore)
+	importer.checkNotTrashed = func(laptopID string) error {
+		err := server.checkNotTrashed(laptopID)
+		if err != nil {
+			//the failures of the summary are plain messages, not status errors
+			return errors.New(status.Convert(err).Message())
+		}
+		return nil
+	}
+	importer.recordRevision = func(laptop *pb.Laptop, version uint64) {
+		server.recordRevision(stream.Context(), pb.LaptopRevision_IMPORT, laptop, version)
+	}
+	first := true
+
+	for {
+		if err := contextError(stream.Context()); err != nil {
+			return err
+		}
+
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return logError(status.Errorf(codes.Unknown, "Cannot receive stream request: %v", err))
+		}
+
+		switch data := req.GetData().(type) {
+		case *pb.ImportCatalogRequest_ImportMode:
+			if !first {
+				return logError(status.Errorf(codes.InvalidArgument, "Import mode must be sent before the entries"))
+			}
+			importer.mode = data.ImportMode
+		case *pb.ImportCatalogRequest_Entry:
+			unlock := server.laptopLocks.lock(data.Entry.GetLaptop().GetId())
+			importer.add(data.Entry)
+			unlock()
+		}
+		first = false
+	}
+
+	summary := importer.summary
+	log.Printf("Imported catalog: %d created, %d updated, %d skipped, %d failed",
+		summary.Created, summary.Updated, summary.Skipped, summary.Failed)
+
+	err := stream.SendAndClose(summary)
+	if err != nil {
+		return logError(status.Errorf(codes.Unknown, "Cannot send response: %v", err))
+	}
+
+	return nil
+}
+
+//ListLaptopRevisions is a unary RPC to page through the history of a laptop, oldest revision first.
+//The history of a deleted laptop can still be listed
+func (server *LaptopServer) ListLaptopRevisions(
+	ctx context.Context,
+	req *pb.ListLaptopRevisionsRequest,
+) (*pb.ListLaptopRevisionsResponse, error) {
+
+	laptopID := req.GetLaptopId()
+	log.Printf("Receive a list laptop revisions request with id: %s", laptopID)
+
+	if server.revisionStore == nil {
+		return nil, status.Errorf(codes.Unimplemented, "Laptop history is not kept")
+	}
+
+	cursor, err := decodePageToken(req.GetPageToken())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	var afterRevision uint64
+	if cursor != "" {
+		afterRevision, err = strconv.ParseUint(cursor, 10, 64)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Page token is malformed")
+		}
+	}
+
+	pageSize := int(req.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	//fetch one more revision than asked for, to find out if there is a next page
+	revisions, err := server.revisionStore.List(laptopID, afterRevision, pageSize+1)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Cannot list revisions: %v", err)
+	}
+
+	res := &pb.ListLaptopRevisionsResponse{}
+	if len(revisions) > pageSize {
+		revisions = revisions[:pageSize]
+		res.NextPageToken = encodePageToken(strconv.FormatUint(revisions[pageSize-1].GetRevision(), 10))
+	}
+	res.Revisions = revisions
+
+	return res, nil
+}
+
+//GetLaptopRevision is a unary RPC to fetch a laptop as of a revision
+func (server *LaptopServer) GetLaptopRevision(
+	ctx context.Context,
+	req *pb.GetLaptopRevisionRequest,
+) (*pb.GetLaptopRevisionResponse, error) {
+
+	log.Printf("Receive a get laptop revision request with id: %s and revision: %d", req.GetLaptopId(), req.GetRevision())
+
+	revision, err := server.findRevision(req.GetLaptopId(), req.GetRevision())
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.GetLaptopRevisionResponse{
+		Revision: revision,
+	}
+
+	return res, nil
+}
+
+//RestoreLaptopRevision is a unary RPC to make a laptop what it was as of a revision, which adds a new revision.
+//A deleted laptop is created again. The restore fails with Aborted if an expected version is given
+//and the stored laptop has moved on
+func (server *LaptopServer) RestoreLaptopRevision(
+	ctx context.Context,
+	req *pb.RestoreLaptopRevisionRequest,
+) (*pb.RestoreLaptopRevisionResponse, error) {
+
+	laptopID := req.GetLaptopId()
+	log.Printf("Receive a restore laptop revision request with id: %s and revision: %d", laptopID, req.GetRevision())
+
+	revision, err := server.findRevision(laptopID, req.GetRevision())
+	if err != nil {
+		return nil, err
+	}
+
+	if revision.GetOperation() == pb.LaptopRevision_DELETE {
+		return nil, status.Errorf(codes.FailedPrecondition, "Revision %d deleted laptop %s, restore an earlier one", revision.GetRevision(), laptopID)
+	}
+
+	laptop := revision.GetLaptop()
+	laptop.UpdatedAt = ptypes.TimestampNow()
+
+	unlock := server.laptopLocks.lock(laptopID)
+	defer unlock()
+
+	existing, err := server.laptopStore.Find(laptopID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Cannot find laptop: %v", err)
+	}
+
+	var version uint64
+	if existing == nil {
+		err = server.checkNotTrashed(laptopID)
+		if err != nil {
+			return nil, err
+		}
+		err = server.laptopStore.Save(laptop)
+		if err == nil {
+			version = savedVersion(server.laptopStore, laptopID)
+		}
+	} else {
+		version, err = server.laptopStore.Update(laptop, req.GetExpectedVersion())
+	}
+	if err != nil {
+		return nil, status.Errorf(storeErrorCode(err), "Cannot restore laptop in the store: %v", err)
+	}
+
+	log.Printf("Restored laptop with id: %s to revision %d", laptopID, revision.GetRevision())
+
+	server.recordRevision(ctx, pb.LaptopRevision_RESTORE, laptop, version)
+
+	res := &pb.RestoreLaptopRevisionResponse{
+		Laptop:  laptop,
+		Version: version,
+	}
+
+	return res, nil
+}
+
+//findRevision returns a revision of a laptop, or a status error if there is none
+func (server *LaptopServer) findRevision(laptopID string, number uint64) (*pb.LaptopRevision, error) {
+	if server.revisionStore == nil {
+		return nil, status.Errorf(codes.Unimplemented, "Laptop history is not kept")
+	}
+
+	revision, err := server.revisionStore.Find(laptopID, number)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Cannot find revision: %v", err)
+	}
+
+	if revision == nil {
+		return nil, status.Errorf(codes.NotFound, "Revision %d of laptop %s is not found", number, laptopID)
+	}
+
+	return revision, nil
+}
+
+//recordRevision appends a revision made by the user of ctx to the history of the laptop, if the history is kept.
+//It is called once the write is committed, so a revision that cannot be recorded is only logged:
+//failing the RPC would make a client retry a write that already happened
+func (server *LaptopServer) recordRevision(
+	ctx context.Context,
+	operation pb.LaptopRevision_Operation,
+	laptop *pb.Laptop,
+	version uint64,
+) {
+	if server.revisionStore == nil {
+		return
+	}
+
+	revision, err := newRevision(ctx, operation, laptop, version)
+	if err != nil {
+		log.Printf("Cannot create revision of laptop %s: %v", laptop.GetId(), err)
+		return
+	}
+
+	_, err = server.revisionStore.Append(revision)
+	if err != nil {
+		log.Printf("Laptop %s is written but its revision cannot be recorded: %v", laptop.GetId(), err)
+	}
+}
+
+//averageRating returns the average score of a laptop, or zero if it isn't rated yet
+func (server *LaptopServer) averageRating(laptopID string) float64 {
+	if server.ratingStore == nil {
+		return 0
+	}
+
+	rating, err := server.ratingStore.Find(laptopID)
+	if err != nil || rating == nil || rating.Count == 0 {
+		return 0
+	}
+
+	return rating.Sum / float64(rating.Count)
+}
+
+func logError(err error) error {
+	if err != nil {
+		log.Print(err)
+	}
+	return err
+}
+
+func storeErrorCode(err error) codes.Code {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return codes.NotFound
+	case errors.Is(err, ErrAlreadyExists):
+		return codes.AlreadyExists
+	case errors.Is(err, ErrVersionMismatch):
+		return codes.Aborted
+	case errors.Is(err, ErrRestoring):
+		return codes.Unavailable
+	case errors.Is(err, ErrImageFormat), errors.Is(err, ErrUnsupportedImageType):
+		return codes.InvalidArgument
+	default:
+		return codes.Internal
 	}
-	return err
 }
 
 func contextError(ctx context.Context) error {
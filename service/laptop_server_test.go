@@ -4,11 +4,17 @@ import (
 	"context"
 	"demo-grpc/pb"
 	"demo-grpc/sample"
+	"errors"
+	"sort"
+	"sync"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 func TestServerCreateLaptop(t *testing.T) {
@@ -67,7 +73,7 @@ func TestServerCreateLaptop(t *testing.T) {
 				Laptop: tc.laptop,
 			}
 
-			server := NewLaptopServer(tc.store, nil, nil)
+			server := NewLaptopServer(tc.store, nil, nil, nil, nil, nil)
 			res, err := server.CreateLaptop(context.Background(), req)
 			if tc.code == codes.OK {
 				require.NoError(t, err)
@@ -86,3 +92,485 @@ func TestServerCreateLaptop(t *testing.T) {
 		})
 	}
 }
+
+func TestServerGetLaptop(t *testing.T) {
+	t.Parallel()
+
+	laptop := sample.NewLaptop()
+	store := NewInMemoryLaptopStore()
+	err := store.Save(laptop)
+	require.NoError(t, err)
+
+	server := NewLaptopServer(store, nil, nil, nil, nil, nil)
+
+	res, err := server.GetLaptop(context.Background(), &pb.GetLaptopRequest{Id: laptop.Id})
+	require.NoError(t, err)
+	requireSameLaptop(t, laptop, res.GetLaptop())
+
+	res, err = server.GetLaptop(context.Background(), &pb.GetLaptopRequest{Id: sample.NewLaptop().Id})
+	require.Nil(t, res)
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestServerUpdateLaptop(t *testing.T) {
+	t.Parallel()
+
+	laptop := sample.NewLaptop()
+	store := NewInMemoryLaptopStore()
+	err := store.Save(laptop)
+	require.NoError(t, err)
+
+	updated := sample.NewLaptop()
+	updated.Id = laptop.Id
+
+	laptopInvalidID := sample.NewLaptop()
+	laptopInvalidID.Id = "invalid-uuid"
+
+	testCases := []struct {
+		name   string
+		laptop *pb.Laptop
+		code   codes.Code
+	}{
+		{
+			name:   "success",
+			laptop: updated,
+			code:   codes.OK,
+		},
+		{
+			name:   "failure_invalid_id",
+			laptop: laptopInvalidID,
+			code:   codes.InvalidArgument,
+		},
+		{
+			name:   "failure_not_found",
+			laptop: sample.NewLaptop(),
+			code:   codes.NotFound,
+		},
+	}
+
+	server := NewLaptopServer(store, nil, nil, nil, nil, nil)
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			req := &pb.UpdateLaptopRequest{
+				Laptop: tc.laptop,
+			}
+
+			res, err := server.UpdateLaptop(context.Background(), req)
+			if tc.code == codes.OK {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+
+				other, err := store.Find(tc.laptop.Id)
+				require.NoError(t, err)
+				requireSameLaptop(t, tc.laptop, other)
+			} else {
+				require.Error(t, err)
+				require.Nil(t, res)
+				require.Equal(t, tc.code, status.Code(err))
+			}
+		})
+	}
+}
+
+func TestServerDeleteLaptop(t *testing.T) {
+	t.Parallel()
+
+	laptop := sample.NewLaptop()
+	store := NewInMemoryLaptopStore()
+	err := store.Save(laptop)
+	require.NoError(t, err)
+
+	server := NewLaptopServer(store, nil, nil, nil, nil, nil)
+
+	res, err := server.DeleteLaptop(context.Background(), &pb.DeleteLaptopRequest{Id: laptop.Id})
+	require.NoError(t, err)
+	require.Equal(t, laptop.Id, res.GetId())
+
+	other, err := store.Find(laptop.Id)
+	require.NoError(t, err)
+	require.Nil(t, other)
+
+	res, err = server.DeleteLaptop(context.Background(), &pb.DeleteLaptopRequest{Id: laptop.Id})
+	require.Nil(t, res)
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestServerUpdateLaptopWithFieldMask(t *testing.T) {
+	t.Parallel()
+
+	laptop := sample.NewLaptop()
+	laptop.UpdatedAt = &timestamp.Timestamp{Seconds: 1}
+	store := NewInMemoryLaptopStore()
+	err := store.Save(laptop)
+	require.NoError(t, err)
+
+	server := NewLaptopServer(store, nil, nil, nil, nil, nil)
+
+	patch := &pb.Laptop{
+		Id:       laptop.Id,
+		PriceUsd: 999,
+		Cpu:      &pb.CPU{MaxGhz: 4.9},
+		Weight:   &pb.Laptop_WeightLb{WeightLb: 3.5},
+	}
+	req := &pb.UpdateLaptopRequest{
+		Laptop:     patch,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"price_usd", "cpu.max_ghz", "weight"}},
+	}
+
+	_, err = server.UpdateLaptop(context.Background(), req)
+	require.NoError(t, err)
+
+	other, err := store.Find(laptop.Id)
+	require.NoError(t, err)
+	require.Equal(t, float64(999), other.GetPriceUsd())
+	require.Equal(t, 4.9, other.GetCpu().GetMaxGhz())
+	require.Equal(t, laptop.GetCpu().GetMinGhz(), other.GetCpu().GetMinGhz())
+	require.Equal(t, laptop.GetCpu().GetName(), other.GetCpu().GetName())
+	require.Equal(t, 3.5, other.GetWeightLb())
+	require.Zero(t, other.GetWeightKg())
+	require.Equal(t, laptop.GetName(), other.GetName())
+	require.Greater(t, other.GetUpdatedAt().GetSeconds(), laptop.GetUpdatedAt().GetSeconds())
+
+	req.UpdateMask.Paths = []string{"cpu.unknown_field"}
+	_, err = server.UpdateLaptop(context.Background(), req)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	req.UpdateMask.Paths = []string{"price_usd.value"}
+	_, err = server.UpdateLaptop(context.Background(), req)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServerLaptopVersionConflict(t *testing.T) {
+	t.Parallel()
+
+	server := NewLaptopServer(NewInMemoryLaptopStore(), nil, nil, nil, nil, nil)
+	ctx := context.Background()
+
+	laptop := sample.NewLaptop()
+	created, err := server.CreateLaptop(ctx, &pb.CreateLaptopRequest{Laptop: laptop})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, created.GetVersion())
+
+	got, err := server.GetLaptop(ctx, &pb.GetLaptopRequest{Id: laptop.Id})
+	require.NoError(t, err)
+	require.Equal(t, created.GetVersion(), got.GetVersion())
+
+	laptop.PriceUsd = 1234
+	updated, err := server.UpdateLaptop(ctx, &pb.UpdateLaptopRequest{Laptop: laptop, ExpectedVersion: got.GetVersion()})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, updated.GetVersion())
+
+	laptop.PriceUsd = 4321
+	_, err = server.UpdateLaptop(ctx, &pb.UpdateLaptopRequest{Laptop: laptop, ExpectedVersion: got.GetVersion()})
+	require.Equal(t, codes.Aborted, status.Code(err))
+
+	_, err = server.UpdateLaptop(ctx, &pb.UpdateLaptopRequest{
+		Laptop:          laptop,
+		UpdateMask:      &fieldmaskpb.FieldMask{Paths: []string{"price_usd"}},
+		ExpectedVersion: got.GetVersion(),
+	})
+	require.Equal(t, codes.Aborted, status.Code(err))
+
+	_, err = server.DeleteLaptop(ctx, &pb.DeleteLaptopRequest{Id: laptop.Id, ExpectedVersion: got.GetVersion()})
+	require.Equal(t, codes.Aborted, status.Code(err))
+
+	_, err = server.DeleteLaptop(ctx, &pb.DeleteLaptopRequest{Id: laptop.Id, ExpectedVersion: updated.GetVersion()})
+	require.NoError(t, err)
+}
+
+func TestServerLaptopRevisions(t *testing.T) {
+	t.Parallel()
+
+	server := NewLaptopServer(NewInMemoryLaptopStore(), nil, nil, NewInMemoryRevisionStore(), nil, nil)
+	ctx := context.WithValue(context.Background(), claimsKey{}, &UserClaims{Username: "admin1", Role: "admin"})
+
+	laptop := sample.NewLaptop()
+	oldPrice := laptop.PriceUsd
+	_, err := server.CreateLaptop(ctx, &pb.CreateLaptopRequest{Laptop: laptop})
+	require.NoError(t, err)
+
+	laptop.PriceUsd = oldPrice + 100
+	_, err = server.UpdateLaptop(ctx, &pb.UpdateLaptopRequest{Laptop: laptop})
+	require.NoError(t, err)
+
+	_, err = server.DeleteLaptop(ctx, &pb.DeleteLaptopRequest{Id: laptop.Id})
+	require.NoError(t, err)
+
+	res, err := server.ListLaptopRevisions(ctx, &pb.ListLaptopRevisionsRequest{LaptopId: laptop.Id, PageSize: 2})
+	require.NoError(t, err)
+	require.Len(t, res.GetRevisions(), 2)
+	require.NotEmpty(t, res.GetNextPageToken())
+
+	created := res.GetRevisions()[0]
+	require.Equal(t, pb.LaptopRevision_CREATE, created.GetOperation())
+	require.Equal(t, "admin1", created.GetUsername())
+	require.NotNil(t, created.GetTimestamp())
+	require.Equal(t, oldPrice, created.GetLaptop().GetPriceUsd())
+	require.Equal(t, pb.LaptopRevision_UPDATE, res.GetRevisions()[1].GetOperation())
+	require.EqualValues(t, 2, res.GetRevisions()[1].GetVersion())
+
+	res, err = server.ListLaptopRevisions(ctx, &pb.ListLaptopRevisionsRequest{LaptopId: laptop.Id, PageToken: res.GetNextPageToken()})
+	require.NoError(t, err)
+	require.Len(t, res.GetRevisions(), 1)
+	require.Empty(t, res.GetNextPageToken())
+	require.Equal(t, pb.LaptopRevision_DELETE, res.GetRevisions()[0].GetOperation())
+
+	//the deleted laptop comes back as it was before the update, at the version after the one it was deleted at
+	_, err = server.RestoreLaptopRevision(ctx, &pb.RestoreLaptopRevisionRequest{LaptopId: laptop.Id, Revision: 3})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	restored, err := server.RestoreLaptopRevision(ctx, &pb.RestoreLaptopRevisionRequest{LaptopId: laptop.Id, Revision: 1})
+	require.NoError(t, err)
+	require.EqualValues(t, 3, restored.GetVersion())
+
+	got, err := server.GetLaptop(ctx, &pb.GetLaptopRequest{Id: laptop.Id})
+	require.NoError(t, err)
+	require.Equal(t, oldPrice, got.GetLaptop().GetPriceUsd())
+
+	//restoring over an existing laptop honours the expected version
+	_, err = server.RestoreLaptopRevision(ctx, &pb.RestoreLaptopRevisionRequest{LaptopId: laptop.Id, Revision: 2, ExpectedVersion: 5})
+	require.Equal(t, codes.Aborted, status.Code(err))
+
+	restored, err = server.RestoreLaptopRevision(ctx, &pb.RestoreLaptopRevisionRequest{LaptopId: laptop.Id, Revision: 2, ExpectedVersion: 3})
+	require.NoError(t, err)
+	require.EqualValues(t, 4, restored.GetVersion())
+	require.Equal(t, oldPrice+100, restored.GetLaptop().GetPriceUsd())
+
+	revision, err := server.GetLaptopRevision(ctx, &pb.GetLaptopRevisionRequest{LaptopId: laptop.Id, Revision: 5})
+	require.NoError(t, err)
+	require.Equal(t, pb.LaptopRevision_RESTORE, revision.GetRevision().GetOperation())
+
+	_, err = server.GetLaptopRevision(ctx, &pb.GetLaptopRevisionRequest{LaptopId: laptop.Id, Revision: 6})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = NewLaptopServer(NewInMemoryLaptopStore(), nil, nil, nil, nil, nil).ListLaptopRevisions(ctx, &pb.ListLaptopRevisionsRequest{LaptopId: laptop.Id})
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestServerConcurrentRevisions(t *testing.T) {
+	t.Parallel()
+
+	server := NewLaptopServer(NewInMemoryLaptopStore(), nil, nil, NewInMemoryRevisionStore(), nil, nil)
+	ctx := context.WithValue(context.Background(), claimsKey{}, &UserClaims{Username: "admin1", Role: "admin"})
+
+	laptop := sample.NewLaptop()
+	_, err := server.CreateLaptop(ctx, &pb.CreateLaptopRequest{Laptop: laptop})
+	require.NoError(t, err)
+
+	const updates = 20
+	var wg sync.WaitGroup
+	for i := 0; i < updates; i++ {
+		wg.Add(1)
+		go func(price float64) {
+			defer wg.Done()
+			other := proto.Clone(laptop).(*pb.Laptop)
+			other.PriceUsd = price
+			_, err := server.UpdateLaptop(ctx, &pb.UpdateLaptopRequest{Laptop: other})
+			require.NoError(t, err)
+		}(float64(1000 + i))
+	}
+	wg.Wait()
+
+	//the history is in the order of the versions, whatever order the updates raced in
+	res, err := server.ListLaptopRevisions(ctx, &pb.ListLaptopRevisionsRequest{LaptopId: laptop.Id, PageSize: 2 * updates})
+	require.NoError(t, err)
+	require.Len(t, res.GetRevisions(), updates+1)
+	for i, revision := range res.GetRevisions() {
+		require.EqualValues(t, i+1, revision.GetRevision())
+		require.EqualValues(t, i+1, revision.GetVersion())
+	}
+}
+
+//failingRevisionStore is a revision store that cannot record any revision
+type failingRevisionStore struct {
+	*InMemoryRevisionStore
+}
+
+func (store failingRevisionStore) Append(revision *pb.LaptopRevision) (uint64, error) {
+	return 0, errors.New("revision log is full")
+}
+
+func TestServerRevisionStoreFails(t *testing.T) {
+	t.Parallel()
+
+	server := NewLaptopServer(NewInMemoryLaptopStore(), nil, nil, failingRevisionStore{NewInMemoryRevisionStore()}, nil, nil)
+	ctx := context.WithValue(context.Background(), claimsKey{}, &UserClaims{Username: "admin1", Role: "admin"})
+
+	//the writes are committed before their revisions are recorded, so they succeed and a retry is never needed
+	laptop := sample.NewLaptop()
+	_, err := server.CreateLaptop(ctx, &pb.CreateLaptopRequest{Laptop: laptop})
+	require.NoError(t, err)
+
+	laptop.PriceUsd++
+	updated, err := server.UpdateLaptop(ctx, &pb.UpdateLaptopRequest{Laptop: laptop, ExpectedVersion: 1})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, updated.GetVersion())
+
+	_, err = server.DeleteLaptop(ctx, &pb.DeleteLaptopRequest{Id: laptop.Id, ExpectedVersion: 2})
+	require.NoError(t, err)
+
+	_, err = server.GetLaptop(ctx, &pb.GetLaptopRequest{Id: laptop.Id})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	res, err := server.ListLaptopRevisions(ctx, &pb.ListLaptopRevisionsRequest{LaptopId: laptop.Id})
+	require.NoError(t, err)
+	require.Empty(t, res.GetRevisions())
+}
+
+func TestServerTrash(t *testing.T) {
+	t.Parallel()
+
+	store := NewInMemoryLaptopStore()
+	trashStore := NewInMemoryTrashStore()
+	server := NewLaptopServer(store, nil, nil, NewInMemoryRevisionStore(), trashStore, nil)
+	ctx := context.WithValue(context.Background(), claimsKey{}, &UserClaims{Username: "admin1", Role: "admin"})
+
+	laptop := sample.NewLaptop()
+	_, err := server.CreateLaptop(ctx, &pb.CreateLaptopRequest{Laptop: laptop})
+	require.NoError(t, err)
+
+	_, err = server.DeleteLaptop(ctx, &pb.DeleteLaptopRequest{Id: laptop.Id, ExpectedVersion: 2})
+	require.Equal(t, codes.Aborted, status.Code(err))
+
+	_, err = server.DeleteLaptop(ctx, &pb.DeleteLaptopRequest{Id: laptop.Id})
+	require.NoError(t, err)
+
+	_, err = server.DeleteLaptop(ctx, &pb.DeleteLaptopRequest{Id: laptop.Id})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	//a soft-deleted laptop is gone from the reads, and its ID cannot be taken
+	_, err = server.GetLaptop(ctx, &pb.GetLaptopRequest{Id: laptop.Id})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	listed, err := server.ListLaptops(ctx, &pb.ListLaptopsRequest{})
+	require.NoError(t, err)
+	require.Empty(t, listed.GetLaptops())
+
+	_, err = server.CreateLaptop(ctx, &pb.CreateLaptopRequest{Laptop: laptop})
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	trash, err := server.ListTrashedLaptops(ctx, &pb.ListTrashedLaptopsRequest{})
+	require.NoError(t, err)
+	require.Len(t, trash.GetLaptops(), 1)
+	require.Equal(t, "admin1", trash.GetLaptops()[0].GetDeletedBy())
+	require.NotNil(t, trash.GetLaptops()[0].GetDeletedAt())
+	requireSameLaptop(t, laptop, trash.GetLaptops()[0].GetLaptop())
+
+	//the restored laptop is past the version it was deleted at, so a client still holding that one cannot write it
+	restored, err := server.RestoreTrashedLaptop(ctx, &pb.RestoreTrashedLaptopRequest{Id: laptop.Id})
+	require.NoError(t, err)
+	require.Equal(t, firstVersion+1, restored.GetVersion())
+
+	_, err = server.DeleteLaptop(ctx, &pb.DeleteLaptopRequest{Id: laptop.Id, ExpectedVersion: firstVersion})
+	require.Equal(t, codes.Aborted, status.Code(err))
+
+	got, err := server.GetLaptop(ctx, &pb.GetLaptopRequest{Id: laptop.Id})
+	require.NoError(t, err)
+	requireSameLaptop(t, laptop, got.GetLaptop())
+
+	_, err = server.RestoreTrashedLaptop(ctx, &pb.RestoreTrashedLaptopRequest{Id: laptop.Id})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	revisions, err := server.ListLaptopRevisions(ctx, &pb.ListLaptopRevisionsRequest{LaptopId: laptop.Id})
+	require.NoError(t, err)
+	require.Len(t, revisions.GetRevisions(), 3)
+	require.Equal(t, pb.LaptopRevision_DELETE, revisions.GetRevisions()[1].GetOperation())
+	require.Equal(t, pb.LaptopRevision_RESTORE, revisions.GetRevisions()[2].GetOperation())
+}
+
+func TestServerListLaptops(t *testing.T) {
+	t.Parallel()
+
+	store := NewInMemoryLaptopStore()
+	for i := 0; i < 25; i++ {
+		err := store.Save(sample.NewLaptop())
+		require.NoError(t, err)
+	}
+
+	server := NewLaptopServer(store, nil, nil, nil, nil, nil)
+	ctx := context.Background()
+
+	req := &pb.ListLaptopsRequest{PageSize: 10}
+	res, err := server.ListLaptops(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, res.GetLaptops(), 10)
+	require.NotEmpty(t, res.GetNextPageToken())
+
+	//laptops created behind the cursor are skipped, the ones ahead of it show up in later pages
+	first := sample.NewLaptop()
+	first.Id = "00000000-0000-0000-0000-000000000000"
+	last := sample.NewLaptop()
+	last.Id = "ffffffff-ffff-ffff-ffff-ffffffffffff"
+	require.NoError(t, store.Save(first))
+	require.NoError(t, store.Save(last))
+
+	ids := []string{}
+	for _, laptop := range res.GetLaptops() {
+		ids = append(ids, laptop.GetId())
+	}
+
+	for res.GetNextPageToken() != "" {
+		req.PageToken = res.GetNextPageToken()
+		res, err = server.ListLaptops(ctx, req)
+		require.NoError(t, err)
+
+		for _, laptop := range res.GetLaptops() {
+			ids = append(ids, laptop.GetId())
+		}
+	}
+
+	require.Len(t, ids, 26)
+	require.True(t, sort.StringsAreSorted(ids))
+	require.Equal(t, last.Id, ids[len(ids)-1])
+	require.NotContains(t, ids, first.Id)
+
+	_, err = server.ListLaptops(ctx, &pb.ListLaptopsRequest{PageToken: "not a token"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServerSearchFacets(t *testing.T) {
+	t.Parallel()
+
+	store := NewInMemoryLaptopStore()
+	prices := []float64{1200, 1450, 1600, 2100, 3900}
+	for i, price := range prices {
+		laptop := sample.NewLaptop()
+		laptop.PriceUsd = price
+		laptop.Brand = "Dell"
+		laptop.Cpu.Brand = "Intel"
+		laptop.Screen.Panel = pb.Screen_IPS
+		laptop.Ram = &pb.Memory{Value: 16, Unit: pb.Memory_GIGABYTE}
+		if i == 0 {
+			laptop.Brand = "Apple"
+			laptop.Screen.Panel = pb.Screen_OLED
+			laptop.Ram = &pb.Memory{Value: 8192, Unit: pb.Memory_MEGABYTE}
+		}
+		require.NoError(t, store.Save(laptop))
+	}
+
+	server := NewLaptopServer(store, nil, nil, nil, nil, nil)
+
+	req := &pb.SearchFacetsRequest{
+		Filter:         &pb.Filter{MaxPriceUsd: 3000},
+		PriceBucketUsd: 500,
+	}
+	res, err := server.SearchFacets(context.Background(), req)
+	require.NoError(t, err)
+
+	require.EqualValues(t, 4, res.GetTotal())
+	require.Equal(t, []*pb.FacetCount{{Value: "Dell", Count: 3}, {Value: "Apple", Count: 1}}, res.GetBrands())
+	require.Equal(t, []*pb.FacetCount{{Value: "Intel", Count: 4}}, res.GetCpuBrands())
+	require.Equal(t, []*pb.FacetCount{{Value: "8-16GB", Count: 1}, {Value: "16-32GB", Count: 3}}, res.GetRam())
+	require.Equal(t, []*pb.FacetCount{{Value: "IPS", Count: 3}, {Value: "OLED", Count: 1}}, res.GetScreenPanels())
+	require.Equal(t, []*pb.PriceBucket{
+		{MinPriceUsd: 1000, MaxPriceUsd: 1500, Count: 2},
+		{MinPriceUsd: 1500, MaxPriceUsd: 2000, Count: 1},
+		{MinPriceUsd: 2000, MaxPriceUsd: 2500, Count: 1},
+	}, res.GetPrices())
+
+	_, err = server.SearchFacets(context.Background(), &pb.SearchFacetsRequest{Query: "ram >="})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -4,34 +4,69 @@ import (
 	"context"
 	"demo-grpc/pb"
 	"errors"
-	"fmt"
 	"log"
 	"sync"
 	//"time"
 
-	"github.com/jinzhu/copier"
+	"github.com/google/btree"
+	"google.golang.org/protobuf/proto"
 )
 
 //ErrAlreadyExists is returned whena record with the same ID already exists in the store
 var ErrAlreadyExists = errors.New("Record already exists")
 
+//ErrNotFound is returned when no record with the given ID exists in the store
+var ErrNotFound = errors.New("Record not found")
+
+//ErrVersionMismatch is returned when a write expects a different version than the stored record has
+var ErrVersionMismatch = errors.New("Record version does not match")
+
+//firstVersion is the version of a laptop right after it is saved for the first time, every update increments it.
+//A laptop saved again with the ID of a deleted one carries on from the last version of that one,
+//so that a version read before the delete never matches the new laptop
+const firstVersion uint64 = 1
+
 //LaptopStore is an interface to store laptop
 type LaptopStore interface {
 	Save(laptop *pb.Laptop) error
 	Find(id string) (*pb.Laptop, error)
-	Search(ctx context.Context, filter *pb.Filter, found func(laptop *pb.Laptop) error) error
+	FindWithVersion(id string) (*pb.Laptop, uint64, error)
+	Update(laptop *pb.Laptop, expectedVersion uint64) (uint64, error)
+	Delete(id string, expectedVersion uint64) error
+	List(ctx context.Context, afterID string, limit int) ([]*pb.Laptop, error)
+	Search(ctx context.Context, filter *pb.Filter, options *SearchOptions, found func(laptop *pb.Laptop) error) error
+}
+
+//LaptopBatchSaver is implemented by laptop stores that can save many laptops in a single transaction
+type LaptopBatchSaver interface {
+	//SaveAll saves every laptop, or none of them if any cannot be saved
+	SaveAll(laptops []*pb.Laptop) error
 }
 
-//InMemoryLaptopStore stores laptop in memory
+//InMemoryLaptopStore stores laptop in memory.
+//Stored laptops are never modified in place, writes replace them with a new copy
 type InMemoryLaptopStore struct {
-	mutex sync.RWMutex
-	data  map[string]*pb.Laptop
+	mutex    sync.RWMutex
+	data     map[string]*pb.Laptop
+	versions map[string]uint64
+	//retired holds the last version of each deleted laptop
+	retired  map[string]uint64
+	ids      *btree.BTree
+	indexes  *laptopIndexes
+	text     *TextIndex
+	watchers *laptopWatchers
 }
 
 //NewInMemoryLaptopStore returns a new InMemoryLaptopStore
 func NewInMemoryLaptopStore() *InMemoryLaptopStore {
 	return &InMemoryLaptopStore{
-		data: make(map[string]*pb.Laptop),
+		data:     make(map[string]*pb.Laptop),
+		versions: make(map[string]uint64),
+		retired:  make(map[string]uint64),
+		ids:      btree.New(indexDegree),
+		indexes:  newLaptopIndexes(),
+		text:     NewTextIndex(),
+		watchers: newLaptopWatchers(),
 	}
 }
 
@@ -44,12 +79,10 @@ func (store *InMemoryLaptopStore) Save(laptop *pb.Laptop) error {
 		return ErrAlreadyExists
 	}
 
-	other, err := deepCopy(laptop)
-	if err != nil {
-		return err
-	}
-
-	store.data[other.Id] = other
+	other := deepCopy(laptop)
+	version := store.retired[other.Id] + 1
+	store.put(other, version)
+	store.watchers.publish(pb.LaptopEvent_CREATED, nil, other, version)
 	return nil
 }
 
@@ -61,54 +94,319 @@ func (store *InMemoryLaptopStore) Find(id string) (*pb.Laptop, error) {
 		return nil, nil
 	}
 
-	return deepCopy(store.data[id])
+	return deepCopy(store.data[id]), nil
 }
 
-//Search searches for laptop with filter, returns one by one via the found function
-func (store *InMemoryLaptopStore) Search(ctx context.Context, filter *pb.Filter, found func(laptop *pb.Laptop) error) error {
+//FindWithVersion finds a laptop by ID and returns it together with its current version
+func (store *InMemoryLaptopStore) FindWithVersion(id string) (*pb.Laptop, uint64, error) {
 	store.mutex.RLock()
 	defer store.mutex.RUnlock()
+	if store.data[id] == nil {
+		return nil, 0, nil
+	}
 
-	for _, laptop := range store.data {
-		//time.Sleep(time.Second)
-		log.Print("Checking laptop id: ", laptop.Id)
+	return deepCopy(store.data[id]), store.versions[id], nil
+}
 
-		if ctx.Err() == context.Canceled || ctx.Err() == context.DeadlineExceeded {
-			log.Print("Context is cancelled")
-			return errors.New("Context is cancelled")
+//Update replaces the stored laptop that has the same ID and returns its new version.
+//If expectedVersion is not zero, it must match the stored version
+func (store *InMemoryLaptopStore) Update(laptop *pb.Laptop, expectedVersion uint64) (uint64, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if store.data[laptop.Id] == nil {
+		return 0, ErrNotFound
+	}
+
+	if expectedVersion != 0 && store.versions[laptop.Id] != expectedVersion {
+		return 0, ErrVersionMismatch
+	}
+
+	other := deepCopy(laptop)
+	version := store.versions[other.Id] + 1
+	old := store.put(other, version)
+	store.watchers.publish(pb.LaptopEvent_UPDATED, old, other, version)
+	return version, nil
+}
+
+//Delete removes a laptop by ID. If expectedVersion is not zero, it must match the stored version
+func (store *InMemoryLaptopStore) Delete(id string, expectedVersion uint64) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if store.data[id] == nil {
+		return ErrNotFound
+	}
+
+	if expectedVersion != 0 && store.versions[id] != expectedVersion {
+		return ErrVersionMismatch
+	}
+
+	version := store.versions[id]
+	old := store.remove(id)
+	store.watchers.publish(pb.LaptopEvent_DELETED, old, nil, version)
+	return nil
+}
+
+//put stores laptop at the given version in place of the laptop with the same ID, and returns that one if any.
+//It must be called with the write lock held
+func (store *InMemoryLaptopStore) put(laptop *pb.Laptop, version uint64) *pb.Laptop {
+	old := store.data[laptop.Id]
+	if old != nil {
+		store.indexes.remove(old)
+	}
+
+	store.data[laptop.Id] = laptop
+	store.versions[laptop.Id] = version
+	delete(store.retired, laptop.Id)
+	store.ids.ReplaceOrInsert(idItem(laptop.Id))
+	store.indexes.insert(laptop)
+	store.text.Add(laptop)
+	return old
+}
+
+//remove removes the laptop with the given ID and returns it, if any.
+//It must be called with the write lock held
+func (store *InMemoryLaptopStore) remove(id string) *pb.Laptop {
+	old := store.data[id]
+	if old == nil {
+		return nil
+	}
+
+	store.indexes.remove(old)
+	store.text.Remove(id)
+	store.retired[id] = store.versions[id]
+	delete(store.data, id)
+	delete(store.versions, id)
+	store.ids.Delete(idItem(id))
+	return old
+}
+
+//version returns the current version of the laptop with the given ID, or 0 if there is no such laptop
+func (store *InMemoryLaptopStore) version(id string) uint64 {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	return store.versions[id]
+}
+
+//nextVersion returns the version a laptop saved with the given ID would have, if there is no such laptop
+func (store *InMemoryLaptopStore) nextVersion(id string) uint64 {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	return store.retired[id] + 1
+}
+
+//apply sets the state of a laptop to the one an event leaves it in, without notifying the watchers.
+//A DELETED event records its version as the last one of the laptop, even if there is no such laptop to remove
+func (store *InMemoryLaptopStore) apply(event *pb.LaptopEvent) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if event.GetType() == pb.LaptopEvent_DELETED {
+		id := event.GetLaptop().GetId()
+		store.remove(id)
+		store.retired[id] = event.GetVersion()
+		return
+	}
+	store.put(deepCopy(event.GetLaptop()), event.GetVersion())
+}
+
+//retiredEvents returns a DELETED event for each deleted laptop, which holds its ID and its last version.
+//It must be called with the read lock held
+func (store *InMemoryLaptopStore) retiredEvents() []*pb.LaptopEvent {
+	events := make([]*pb.LaptopEvent, 0, len(store.retired))
+	for id, version := range store.retired {
+		events = append(events, &pb.LaptopEvent{
+			Type:    pb.LaptopEvent_DELETED,
+			Laptop:  &pb.Laptop{Id: id},
+			Version: version,
+		})
+	}
+	return events
+}
+
+//existing returns an EXISTING event for each laptop matching the query, in ID order.
+//It must be called with the read lock held, and the events share the laptops of the store
+func (store *InMemoryLaptopStore) existing(query *Query) []*pb.LaptopEvent {
+	events := []*pb.LaptopEvent{}
+	store.ids.Ascend(func(item btree.Item) bool {
+		id := string(item.(idItem))
+		if query.Match(store.data[id]) {
+			events = append(events, &pb.LaptopEvent{
+				Type:    pb.LaptopEvent_EXISTING,
+				Laptop:  store.data[id],
+				Version: store.versions[id],
+			})
 		}
+		return true
+	})
+	return events
+}
+
+//List returns up to limit laptops in ascending ID order, starting right after afterID.
+//An empty afterID starts from the beginning of the store
+func (store *InMemoryLaptopStore) List(ctx context.Context, afterID string, limit int) ([]*pb.Laptop, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
 
-		if isQualified(filter, laptop) {
-			other, err := deepCopy(laptop)
-			if err != nil {
-				return err
-			}
-			err = found(other)
-			if err != nil {
-				return err
-			}
+	laptops := make([]*pb.Laptop, 0, limit)
+	var err error
+
+	store.ids.AscendGreaterOrEqual(idItem(afterID), func(item btree.Item) bool {
+		id := string(item.(idItem))
+		if id == afterID {
+			return true
+		}
+
+		if err = ctx.Err(); err != nil {
+			return false
+		}
+
+		laptops = append(laptops, deepCopy(store.data[id]))
+		return len(laptops) < limit
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return laptops, nil
+}
+
+//Search searches for laptop with filter and the query of options, returns one by one via the found function.
+//Laptops are returned in the order given by options, and no more than its MaxResults if that is set
+func (store *InMemoryLaptopStore) Search(
+	ctx context.Context,
+	filter *pb.Filter,
+	options *SearchOptions,
+	found func(laptop *pb.Laptop) error,
+) error {
+	query := CompileFilter(filter).And(options.query())
+
+	store.mutex.RLock()
+	var plan searchPlan
+	if options.text() != "" {
+		plan = searchPlan{matches: store.text.Search(options.text())}
+		options = options.withRelevance(plan.matches)
+	} else {
+		plan = store.indexes.plan(filter, len(store.data))
+	}
+	if plan.index != nil {
+		log.Printf("Searching laptops with the %s index", plan.index.name)
+	}
+	laptops, err := store.collect(ctx, plan, query, options)
+	store.mutex.RUnlock()
+
+	if err != nil {
+		return err
+	}
+
+	//the collected laptops are never modified by the store, so they are copied and sent without holding the lock
+	for _, laptop := range laptops {
+		err := found(deepCopy(laptop))
+		if err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
-func isQualified(filter *pb.Filter, laptop *pb.Laptop) bool {
-	if laptop.GetPriceUsd() > filter.GetMaxPriceUsd() {
-		return false
+//Watch sends the laptops matching the filter first if initial is true, then every change to a laptop
+//that matches the filter before or after the change, until ctx is done or found returns an error.
+//The changes are buffered for each watcher, a watcher too slow to keep up gets ErrWatcherTooSlow
+func (store *InMemoryLaptopStore) Watch(
+	ctx context.Context,
+	filter *pb.Filter,
+	initial bool,
+	found func(event *pb.LaptopEvent) error,
+) error {
+	query := CompileFilter(filter)
+
+	//the watcher is added under the read lock, so no change is missed or sent twice between the initial laptops and the events
+	store.mutex.RLock()
+	w := store.watchers.add(query)
+	defer store.watchers.remove(w)
+
+	events := []*pb.LaptopEvent{}
+	if initial {
+		events = store.existing(query)
 	}
-	if laptop.GetCpu().GetNumberCores() < filter.MinCpuCores {
-		return false
+	store.mutex.RUnlock()
+
+	return w.run(ctx, events, found)
+}
+
+//collect returns the laptops of the plan that match the query, in the order and number given by options.
+//It must be called with the read lock held
+func (store *InMemoryLaptopStore) collect(
+	ctx context.Context,
+	plan searchPlan,
+	query *Query,
+	options *SearchOptions,
+) ([]*pb.Laptop, error) {
+	var results *sortedResults
+	if options.sorted() {
+		results = newSortedResults(options)
+	}
+
+	matches := []*pb.Laptop{}
+	limit := options.maxResults()
+	var err error
+
+	plan.ascend(store.ids, func(id string) bool {
+		//time.Sleep(time.Second)
+		if ctx.Err() == context.Canceled || ctx.Err() == context.DeadlineExceeded {
+			log.Print("Context is cancelled")
+			err = errors.New("Context is cancelled")
+			return false
+		}
+
+		laptop := store.data[id]
+		if !query.Match(laptop) {
+			return true
+		}
+
+		if results != nil {
+			results.add(laptop)
+			return true
+		}
+
+		matches = append(matches, laptop)
+		return len(matches) != limit
+	})
+
+	if err != nil {
+		return nil, err
 	}
-	if laptop.GetCpu().GetMinGhz() < filter.MinCpuGhz {
-		return false
+
+	if results != nil {
+		return results.laptops(), nil
 	}
 
-	if toBit(laptop.GetRam()) < toBit(filter.GetMinRam()) {
-		return false
+	return matches, nil
+}
+
+//totalStorage returns the capacity in bits of all storages of the laptop with the given driver
+func totalStorage(laptop *pb.Laptop, driver pb.Storage_Driver) uint64 {
+	total := uint64(0)
+	for _, storage := range laptop.GetStorages() {
+		if storage.GetDriver() == driver {
+			total += toBit(storage.GetMemory())
+		}
 	}
+	return total
+}
 
-	return true
+const kgPerLb = 0.45359237
+
+//weightKg returns the weight of the laptop in kilograms, whichever unit it was given in
+func weightKg(laptop *pb.Laptop) float64 {
+	if _, ok := laptop.GetWeight().(*pb.Laptop_WeightLb); ok {
+		return laptop.GetWeightLb() * kgPerLb
+	}
+	return laptop.GetWeightKg()
 }
 
 func toBit(memory *pb.Memory) uint64 {
@@ -132,12 +430,6 @@ func toBit(memory *pb.Memory) uint64 {
 	}
 }
 
-func deepCopy(laptop *pb.Laptop) (*pb.Laptop, error) {
-	other := &pb.Laptop{}
-	err := copier.Copy(other, laptop)
-	if err != nil {
-		return nil, fmt.Errorf("Cannot copy laptop data: %v", err)
-	}
-
-	return other, nil
+func deepCopy(laptop *pb.Laptop) *pb.Laptop {
+	return proto.Clone(laptop).(*pb.Laptop)
 }
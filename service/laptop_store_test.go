@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"demo-grpc/pb"
+	"demo-grpc/sample"
+	"errors"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileFilter(t *testing.T) {
+	t.Parallel()
+
+	laptop := sample.NewLaptop()
+	laptop.Brand = "Lenovo"
+	laptop.Name = "Thinkpad X1"
+	laptop.PriceUsd = 2000
+	laptop.ReleaseYear = 2018
+	laptop.Weight = &pb.Laptop_WeightLb{WeightLb: 4}
+	laptop.Gpus = []*pb.GPU{
+		{Brand: "Nvidia", Memory: &pb.Memory{Value: 6, Unit: pb.Memory_GIGABYTE}},
+	}
+	laptop.Storages = []*pb.Storage{
+		{Driver: pb.Storage_SSD, Memory: &pb.Memory{Value: 512, Unit: pb.Memory_GIGABYTE}},
+		{Driver: pb.Storage_SSD, Memory: &pb.Memory{Value: 512, Unit: pb.Memory_GIGABYTE}},
+	}
+	laptop.Screen = &pb.Screen{
+		SizeInch:   14,
+		Resolution: &pb.Screen_Resolution{Width: 2560, Height: 1440},
+		Panel:      pb.Screen_IPS,
+		Multitouch: false,
+	}
+	laptop.Keyboard = &pb.Keyboard{Layout: pb.Keyboard_QWERTY, Backlit: true}
+
+	testCases := []struct {
+		name   string
+		filter *pb.Filter
+		match  bool
+	}{
+		{"empty_filter", &pb.Filter{}, true},
+		{"nil_filter", nil, true},
+		{"max_price", &pb.Filter{MaxPriceUsd: 1999}, false},
+		{"min_price", &pb.Filter{MinPriceUsd: 2000}, true},
+		{"brand_case_insensitive", &pb.Filter{Brand: "lenovo"}, true},
+		{"other_brand", &pb.Filter{Brand: "Dell"}, false},
+		{"name_substring", &pb.Filter{Name: "thinkpad"}, true},
+		{"gpu_brand", &pb.Filter{GpuBrand: "AMD"}, false},
+		{"gpu_memory", &pb.Filter{GpuBrand: "nvidia", MinGpuMemory: &pb.Memory{Value: 6144, Unit: pb.Memory_MEGABYTE}}, true},
+		{"gpu_memory_too_small", &pb.Filter{MinGpuMemory: &pb.Memory{Value: 8, Unit: pb.Memory_GIGABYTE}}, false},
+		{"total_ssd", &pb.Filter{MinSsd: &pb.Memory{Value: 1, Unit: pb.Memory_TERABYTE}}, true},
+		{"no_hdd", &pb.Filter{MinHdd: &pb.Memory{Value: 1, Unit: pb.Memory_GIGABYTE}}, false},
+		{"screen_size", &pb.Filter{MinScreenSizeInch: 13, MaxScreenSizeInch: 15}, true},
+		{"screen_too_small", &pb.Filter{MinScreenSizeInch: 15}, false},
+		{"resolution", &pb.Filter{MinResolution: &pb.Screen_Resolution{Width: 3840, Height: 2160}}, false},
+		{"panel", &pb.Filter{Panel: pb.Screen_OLED}, false},
+		{"multitouch", &pb.Filter{Multitouch: &wrappers.BoolValue{Value: false}}, true},
+		{"weight_lb_normalised", &pb.Filter{MinWeightKg: 1.8, MaxWeightKg: 1.9}, true},
+		{"too_heavy", &pb.Filter{MaxWeightKg: 1.5}, false},
+		{"release_year", &pb.Filter{MinReleaseYear: 2017, MaxReleaseYear: 2018}, true},
+		{"too_old", &pb.Filter{MinReleaseYear: 2019}, false},
+		{"keyboard_layout", &pb.Filter{KeyboardLayout: pb.Keyboard_AZERTY}, false},
+		{"backlit", &pb.Filter{Backlit: &wrappers.BoolValue{Value: true}}, true},
+		{"not_backlit", &pb.Filter{Backlit: &wrappers.BoolValue{Value: false}}, false},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.match, CompileFilter(tc.filter).Match(laptop))
+		})
+	}
+}
+
+func TestInMemoryLaptopStoreSearchSorted(t *testing.T) {
+	t.Parallel()
+
+	store := NewInMemoryLaptopStore()
+	prices := []float64{}
+	for i := 0; i < 30; i++ {
+		laptop := sample.NewLaptop()
+		prices = append(prices, laptop.GetPriceUsd())
+		require.NoError(t, store.Save(laptop))
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(prices)))
+
+	search := func(options *SearchOptions) []*pb.Laptop {
+		laptops := []*pb.Laptop{}
+		err := store.Search(context.Background(), &pb.Filter{}, options, func(laptop *pb.Laptop) error {
+			laptops = append(laptops, laptop)
+			return nil
+		})
+		require.NoError(t, err)
+		return laptops
+	}
+
+	laptops := search(&SearchOptions{SortBy: pb.SearchLaptopRequest_PRICE, Descending: true, MaxResults: 5})
+	require.Len(t, laptops, 5)
+	for i, laptop := range laptops {
+		require.Equal(t, prices[i], laptop.GetPriceUsd())
+	}
+
+	laptops = search(&SearchOptions{SortBy: pb.SearchLaptopRequest_PRICE})
+	require.Len(t, laptops, 30)
+	require.Equal(t, prices[29], laptops[0].GetPriceUsd())
+
+	favorite := laptops[17].GetId()
+	laptops = search(&SearchOptions{
+		SortBy:     pb.SearchLaptopRequest_RATING,
+		Descending: true,
+		MaxResults: 1,
+		Rating: func(laptopID string) float64 {
+			if laptopID == favorite {
+				return 10
+			}
+			return 5
+		},
+	})
+	require.Len(t, laptops, 1)
+	require.Equal(t, favorite, laptops[0].GetId())
+
+	laptops = search(&SearchOptions{MaxResults: 3})
+	require.Len(t, laptops, 3)
+}
+
+func TestInMemoryLaptopStoreSearchText(t *testing.T) {
+	t.Parallel()
+
+	store := NewInMemoryLaptopStore()
+	newLaptop := func(brand, name, cpu string, price float64) *pb.Laptop {
+		laptop := sample.NewLaptop()
+		laptop.Brand = brand
+		laptop.Name = name
+		laptop.Cpu.Name = cpu
+		laptop.Gpus = nil
+		laptop.PriceUsd = price
+		require.NoError(t, store.Save(laptop))
+		return laptop
+	}
+
+	carbon := newLaptop("Lenovo", "Thinkpad X1 Carbon", "Core i7-8650U", 2500)
+	yoga := newLaptop("Lenovo", "Yoga i7 Edition", "Core i5-8250U", 1200)
+	p1 := newLaptop("Lenovo", "Thinkpad P1", "Core i7-9850H", 1800)
+	newLaptop("Lenovo", "Thinkpad T480", "Core i5-8350U", 1100)
+	newLaptop("Dell", "XPS 13", "Core i7-8550U", 1500)
+
+	search := func(filter *pb.Filter, options *SearchOptions) []string {
+		ids := []string{}
+		err := store.Search(context.Background(), filter, options, func(laptop *pb.Laptop) error {
+			ids = append(ids, laptop.GetId())
+			return nil
+		})
+		require.NoError(t, err)
+		return ids
+	}
+
+	ids := search(nil, &SearchOptions{Text: "ThinkPad i7"})
+	require.ElementsMatch(t, []string{carbon.Id, p1.Id}, ids)
+
+	ids = search(nil, &SearchOptions{Text: "lenovo i7"})
+	require.Len(t, ids, 3)
+	require.Equal(t, yoga.Id, ids[0], "a match in the name ranks first")
+
+	ids = search(nil, &SearchOptions{Text: "think"})
+	require.Len(t, ids, 3)
+
+	ids = search(&pb.Filter{MaxPriceUsd: 2000}, &SearchOptions{Text: "thinkpad i7"})
+	require.Equal(t, []string{p1.Id}, ids)
+
+	ids = search(nil, &SearchOptions{Text: "thinkpad", SortBy: pb.SearchLaptopRequest_PRICE, Descending: true})
+	require.Len(t, ids, 3)
+	require.Equal(t, carbon.Id, ids[0])
+
+	ids = search(nil, &SearchOptions{Text: "macbook"})
+	require.Empty(t, ids)
+
+	require.NoError(t, store.Delete(carbon.Id, 0))
+	ids = search(nil, &SearchOptions{Text: "carbon"})
+	require.Empty(t, ids)
+}
+
+func TestInMemoryLaptopStoreWatchSlowWatcher(t *testing.T) {
+	t.Parallel()
+
+	store := NewInMemoryLaptopStore()
+	require.NoError(t, store.Save(sample.NewLaptop()))
+
+	watching := make(chan struct{})
+	blocked := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- store.Watch(context.Background(), nil, true, func(event *pb.LaptopEvent) error {
+			if event.GetType() == pb.LaptopEvent_EXISTING {
+				close(watching)
+			}
+			<-blocked
+			return nil
+		})
+	}()
+	<-watching
+
+	//writers must not wait for a watcher that does not read its events
+	for i := 0; i < watchBufferSize+1; i++ {
+		require.NoError(t, store.Save(sample.NewLaptop()))
+	}
+	close(blocked)
+
+	err := <-done
+	require.True(t, errors.Is(err, ErrWatcherTooSlow))
+}
+
+func TestInMemoryLaptopStoreWatchFilter(t *testing.T) {
+	t.Parallel()
+
+	store := NewInMemoryLaptopStore()
+	laptop := sample.NewLaptop()
+	laptop.Brand = "Dell"
+	require.NoError(t, store.Save(laptop))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := make(chan *pb.LaptopEvent, 10)
+	done := make(chan error)
+	go func() {
+		done <- store.Watch(ctx, &pb.Filter{Brand: "Dell"}, true, func(event *pb.LaptopEvent) error {
+			events <- event
+			return nil
+		})
+	}()
+	require.Equal(t, pb.LaptopEvent_EXISTING, (<-events).GetType())
+
+	for _, brand := range []string{"Dell", "Lenovo", "Lenovo", "Dell"} {
+		laptop.Brand = brand
+		_, err := store.Update(laptop, 0)
+		require.NoError(t, err)
+	}
+
+	//a laptop leaving the filter is sent once as LEFT, then not at all until it matches again
+	expected := []struct {
+		eventType pb.LaptopEvent_Type
+		version   uint64
+	}{
+		{pb.LaptopEvent_UPDATED, 2},
+		{pb.LaptopEvent_LEFT, 3},
+		{pb.LaptopEvent_UPDATED, 5},
+	}
+	for _, want := range expected {
+		event := <-events
+		require.Equal(t, want.eventType, event.GetType())
+		require.Equal(t, want.version, event.GetVersion())
+		require.Equal(t, laptop.Id, event.GetLaptop().GetId())
+	}
+
+	cancel()
+	<-done
+	require.Empty(t, events)
+}
+
+func TestLaptopIndexesPlan(t *testing.T) {
+	t.Parallel()
+
+	store := NewInMemoryLaptopStore()
+	for i := 0; i < 50; i++ {
+		laptop := sample.NewLaptop()
+		laptop.PriceUsd = 1000
+		laptop.Ram = &pb.Memory{Value: 8, Unit: pb.Memory_GIGABYTE}
+		if i < 5 {
+			laptop.Ram.Value = 64
+		}
+		require.NoError(t, store.Save(laptop))
+	}
+
+	filter := &pb.Filter{
+		MaxPriceUsd: 2000,
+		MinRam:      &pb.Memory{Value: 32, Unit: pb.Memory_GIGABYTE},
+	}
+	plan := store.indexes.plan(filter, len(store.data))
+	require.Equal(t, store.indexes.ram, plan.index)
+
+	plan = store.indexes.plan(&pb.Filter{MaxPriceUsd: 2000}, len(store.data))
+	require.Nil(t, plan.index)
+
+	found := 0
+	err := store.Search(context.Background(), filter, nil, func(laptop *pb.Laptop) error {
+		found++
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 5, found)
+}
+
+func newBenchmarkLaptopStore(b *testing.B, n int) *InMemoryLaptopStore {
+	store := NewInMemoryLaptopStore()
+	for i := 0; i < n; i++ {
+		err := store.Save(sample.NewLaptop())
+		require.NoError(b, err)
+	}
+	return store
+}
+
+func BenchmarkInMemoryLaptopStoreSearch(b *testing.B) {
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	store := newBenchmarkLaptopStore(b, 100000)
+	filter := &pb.Filter{
+		MaxPriceUsd: 1550,
+		MinCpuCores: 4,
+		MinRam:      &pb.Memory{Value: 16, Unit: pb.Memory_GIGABYTE},
+	}
+	ctx := context.Background()
+
+	b.Run("indexed", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			err := store.Search(ctx, filter, nil, func(laptop *pb.Laptop) error { return nil })
+			require.NoError(b, err)
+		}
+	})
+
+	b.Run("linear_scan", func(b *testing.B) {
+		query := CompileFilter(filter)
+		for i := 0; i < b.N; i++ {
+			store.mutex.RLock()
+			laptops, err := store.collect(ctx, searchPlan{}, query, nil)
+			store.mutex.RUnlock()
+			require.NoError(b, err)
+
+			for _, laptop := range laptops {
+				deepCopy(laptop)
+			}
+		}
+	})
+}
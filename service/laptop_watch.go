@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"demo-grpc/pb"
+	"errors"
+	"sync"
+)
+
+//watchBufferSize is the number of events buffered for each watcher before it is dropped
+const watchBufferSize = 256
+
+//ErrWatcherTooSlow is returned to a watcher that fell so far behind that its buffer filled up
+var ErrWatcherTooSlow = errors.New("Watcher is too slow to keep up with the changes")
+
+//LaptopWatcher is implemented by laptop stores that can stream the changes of their laptops
+type LaptopWatcher interface {
+	//Watch sends the laptops matching the filter first if initial is true, then every change to a laptop
+	//that matches the filter before or after the change, until ctx is done or found returns an error.
+	//An update after which the laptop no longer matches the filter is sent as a LEFT event
+	Watch(ctx context.Context, filter *pb.Filter, initial bool, found func(event *pb.LaptopEvent) error) error
+}
+
+//watcher is one subscriber to the changes of a store, its events are buffered so that writers never wait for it
+type watcher struct {
+	query  *Query
+	events chan *pb.LaptopEvent
+}
+
+//laptopWatchers fans the changes of a store out to its watchers
+type laptopWatchers struct {
+	mutex    sync.Mutex
+	watchers map[*watcher]bool
+}
+
+func newLaptopWatchers() *laptopWatchers {
+	return &laptopWatchers{
+		watchers: make(map[*watcher]bool),
+	}
+}
+
+func (watchers *laptopWatchers) add(query *Query) *watcher {
+	watchers.mutex.Lock()
+	defer watchers.mutex.Unlock()
+
+	w := &watcher{
+		query:  query,
+		events: make(chan *pb.LaptopEvent, watchBufferSize),
+	}
+	watchers.watchers[w] = true
+	return w
+}
+
+func (watchers *laptopWatchers) remove(w *watcher) {
+	watchers.mutex.Lock()
+	defer watchers.mutex.Unlock()
+
+	delete(watchers.watchers, w)
+}
+
+//publish sends an event to the watchers whose query matches the old or the new laptop, either can be nil.
+//A watcher whose query only matches the old laptop of an update gets a LEFT event instead.
+//A watcher whose buffer is full is dropped, its events channel is closed
+func (watchers *laptopWatchers) publish(eventType pb.LaptopEvent_Type, old, new *pb.Laptop, version uint64) {
+	watchers.mutex.Lock()
+	defer watchers.mutex.Unlock()
+
+	laptop := new
+	if laptop == nil {
+		laptop = old
+	}
+	event := &pb.LaptopEvent{
+		Type:    eventType,
+		Laptop:  laptop,
+		Version: version,
+	}
+	left := &pb.LaptopEvent{
+		Type:    pb.LaptopEvent_LEFT,
+		Laptop:  laptop,
+		Version: version,
+	}
+
+	for w := range watchers.watchers {
+		matchOld := old != nil && w.query.Match(old)
+		matchNew := new != nil && w.query.Match(new)
+		if !matchOld && !matchNew {
+			continue
+		}
+
+		event := event
+		if eventType == pb.LaptopEvent_UPDATED && !matchNew {
+			event = left
+		}
+
+		select {
+		case w.events <- event:
+		default:
+			delete(watchers.watchers, w)
+			close(w.events)
+		}
+	}
+}
+
+//run sends the initial events then the buffered ones to found, each with a copy of its laptop
+func (w *watcher) run(ctx context.Context, initial []*pb.LaptopEvent, found func(event *pb.LaptopEvent) error) error {
+	send := func(event *pb.LaptopEvent) error {
+		return found(&pb.LaptopEvent{
+			Type:    event.GetType(),
+			Laptop:  deepCopy(event.GetLaptop()),
+			Version: event.GetVersion(),
+		})
+	}
+
+	for _, event := range initial {
+		if ctx.Err() != nil {
+			return errors.New("Context is cancelled")
+		}
+
+		err := send(event)
+		if err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.New("Context is cancelled")
+		case event, ok := <-w.events:
+			if !ok {
+				return ErrWatcherTooSlow
+			}
+
+			err := send(event)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
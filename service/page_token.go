@@ -0,0 +1,33 @@
+package service
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+const pageTokenPrefix = "after:"
+
+//encodePageToken returns an opaque token that resumes listing right after the laptop with the given ID
+func encodePageToken(lastID string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(pageTokenPrefix + lastID))
+}
+
+//decodePageToken returns the laptop ID a page token resumes after
+func decodePageToken(token string) (string, error) {
+	if token == "" {
+		return "", nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", fmt.Errorf("Cannot decode page token: %v", err)
+	}
+
+	cursor := string(data)
+	if !strings.HasPrefix(cursor, pageTokenPrefix) {
+		return "", fmt.Errorf("Page token is malformed")
+	}
+
+	return strings.TrimPrefix(cursor, pageTokenPrefix), nil
+}
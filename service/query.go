@@ -0,0 +1,350 @@
+package service
+
+import (
+	"demo-grpc/pb"
+	"strings"
+)
+
+//Query is a boolean expression over laptop fields, parsed from text or compiled from a filter
+type Query struct {
+	expr queryExpr
+}
+
+//Match reports whether the laptop satisfies the query, a nil query matches every laptop
+func (query *Query) Match(laptop *pb.Laptop) bool {
+	if query == nil {
+		return true
+	}
+	return query.expr.eval(&queryEnv{laptop: laptop})
+}
+
+//And returns a query that matches the laptops matched by both queries
+func (query *Query) And(other *Query) *Query {
+	if query == nil {
+		return other
+	}
+	if other == nil {
+		return query
+	}
+	return &Query{expr: &andExpr{left: query.expr, right: other.expr}}
+}
+
+//queryEnv is what an expression is evaluated against, gpu is set inside a GPU expression
+type queryEnv struct {
+	laptop *pb.Laptop
+	gpu    *pb.GPU
+}
+
+type queryExpr interface {
+	eval(env *queryEnv) bool
+}
+
+type trueExpr struct{}
+
+func (expr *trueExpr) eval(env *queryEnv) bool { return true }
+
+type andExpr struct{ left, right queryExpr }
+
+func (expr *andExpr) eval(env *queryEnv) bool { return expr.left.eval(env) && expr.right.eval(env) }
+
+type orExpr struct{ left, right queryExpr }
+
+func (expr *orExpr) eval(env *queryEnv) bool { return expr.left.eval(env) || expr.right.eval(env) }
+
+type notExpr struct{ expr queryExpr }
+
+func (expr *notExpr) eval(env *queryEnv) bool { return !expr.expr.eval(env) }
+
+//gpuExpr matches if at least one GPU of the laptop satisfies its inner expression
+type gpuExpr struct{ expr queryExpr }
+
+func (expr *gpuExpr) eval(env *queryEnv) bool {
+	for _, gpu := range env.laptop.GetGpus() {
+		if expr.expr.eval(&queryEnv{laptop: env.laptop, gpu: gpu}) {
+			return true
+		}
+	}
+	return false
+}
+
+type compareOp int
+
+const (
+	opEqual compareOp = iota
+	opNotEqual
+	opLess
+	opLessEqual
+	opGreater
+	opGreaterEqual
+	opContains
+)
+
+var compareOps = map[string]compareOp{
+	"=":  opEqual,
+	"==": opEqual,
+	"!=": opNotEqual,
+	"<":  opLess,
+	"<=": opLessEqual,
+	">":  opGreater,
+	">=": opGreaterEqual,
+	"~":  opContains,
+}
+
+type fieldKind int
+
+const (
+	stringField fieldKind = iota
+	numberField
+	memoryField
+	boolField
+	enumField
+)
+
+func (kind fieldKind) String() string {
+	switch kind {
+	case stringField:
+		return "text"
+	case numberField:
+		return "number"
+	case memoryField:
+		return "memory"
+	case boolField:
+		return "boolean"
+	default:
+		return "enum"
+	}
+}
+
+//queryField is a laptop field that can be used in a query, only the getter of its kind is set
+type queryField struct {
+	name  string
+	kind  fieldKind
+	gpu   bool
+	enum  map[string]int32
+	str   func(env *queryEnv) string
+	num   func(env *queryEnv) float64
+	bits  func(env *queryEnv) uint64
+	flag  func(env *queryEnv) bool
+	value func(env *queryEnv) int32
+}
+
+//queryValue is the literal a field is compared with
+type queryValue struct {
+	str  string
+	num  float64
+	bits uint64
+	flag bool
+	enum int32
+}
+
+type compareExpr struct {
+	field *queryField
+	op    compareOp
+	value queryValue
+}
+
+func (expr *compareExpr) eval(env *queryEnv) bool {
+	switch expr.field.kind {
+	case stringField:
+		return compareStrings(expr.field.str(env), expr.op, expr.value.str)
+	case numberField:
+		return compareNumbers(expr.field.num(env), expr.op, expr.value.num)
+	case memoryField:
+		return compareBits(expr.field.bits(env), expr.op, expr.value.bits)
+	case boolField:
+		return (expr.field.flag(env) == expr.value.flag) == (expr.op == opEqual)
+	default:
+		return (expr.field.value(env) == expr.value.enum) == (expr.op == opEqual)
+	}
+}
+
+func compareStrings(a string, op compareOp, b string) bool {
+	switch op {
+	case opEqual:
+		return strings.EqualFold(a, b)
+	case opNotEqual:
+		return !strings.EqualFold(a, b)
+	default:
+		return strings.Contains(strings.ToLower(a), strings.ToLower(b))
+	}
+}
+
+func compareNumbers(a float64, op compareOp, b float64) bool {
+	switch op {
+	case opEqual:
+		return a == b
+	case opNotEqual:
+		return a != b
+	case opLess:
+		return a < b
+	case opLessEqual:
+		return a <= b
+	case opGreater:
+		return a > b
+	default:
+		return a >= b
+	}
+}
+
+func compareBits(a uint64, op compareOp, b uint64) bool {
+	switch op {
+	case opEqual:
+		return a == b
+	case opNotEqual:
+		return a != b
+	case opLess:
+		return a < b
+	case opLessEqual:
+		return a <= b
+	case opGreater:
+		return a > b
+	default:
+		return a >= b
+	}
+}
+
+var queryFields = map[string]*queryField{}
+
+func addQueryField(field *queryField, aliases ...string) {
+	queryFields[field.name] = field
+	for _, alias := range aliases {
+		queryFields[alias] = field
+	}
+}
+
+func init() {
+	addQueryField(&queryField{name: "id", kind: stringField, str: func(env *queryEnv) string { return env.laptop.GetId() }})
+	addQueryField(&queryField{name: "brand", kind: stringField, str: func(env *queryEnv) string { return env.laptop.GetBrand() }})
+	addQueryField(&queryField{name: "name", kind: stringField, str: func(env *queryEnv) string { return env.laptop.GetName() }})
+	addQueryField(&queryField{name: "price", kind: numberField, num: func(env *queryEnv) float64 { return env.laptop.GetPriceUsd() }}, "price_usd")
+	addQueryField(&queryField{name: "release_year", kind: numberField, num: func(env *queryEnv) float64 { return float64(env.laptop.GetReleaseYear()) }}, "year")
+	addQueryField(&queryField{name: "weight", kind: numberField, num: func(env *queryEnv) float64 { return weightKg(env.laptop) }}, "weight_kg")
+
+	addQueryField(&queryField{name: "cpu.brand", kind: stringField, str: func(env *queryEnv) string { return env.laptop.GetCpu().GetBrand() }})
+	addQueryField(&queryField{name: "cpu.name", kind: stringField, str: func(env *queryEnv) string { return env.laptop.GetCpu().GetName() }})
+	addQueryField(&queryField{name: "cpu.cores", kind: numberField, num: func(env *queryEnv) float64 { return float64(env.laptop.GetCpu().GetNumberCores()) }})
+	addQueryField(&queryField{name: "cpu.threads", kind: numberField, num: func(env *queryEnv) float64 { return float64(env.laptop.GetCpu().GetNumberThreads()) }})
+	addQueryField(&queryField{name: "cpu.min_ghz", kind: numberField, num: func(env *queryEnv) float64 { return env.laptop.GetCpu().GetMinGhz() }}, "cpu.ghz")
+	addQueryField(&queryField{name: "cpu.max_ghz", kind: numberField, num: func(env *queryEnv) float64 { return env.laptop.GetCpu().GetMaxGhz() }})
+
+	addQueryField(&queryField{name: "ram", kind: memoryField, bits: func(env *queryEnv) uint64 { return toBit(env.laptop.GetRam()) }})
+	addQueryField(&queryField{name: "ssd", kind: memoryField, bits: func(env *queryEnv) uint64 { return totalStorage(env.laptop, pb.Storage_SSD) }})
+	addQueryField(&queryField{name: "hdd", kind: memoryField, bits: func(env *queryEnv) uint64 { return totalStorage(env.laptop, pb.Storage_HDD) }})
+
+	addQueryField(&queryField{name: "gpu.brand", kind: stringField, gpu: true, str: func(env *queryEnv) string { return env.gpu.GetBrand() }})
+	addQueryField(&queryField{name: "gpu.name", kind: stringField, gpu: true, str: func(env *queryEnv) string { return env.gpu.GetName() }})
+	addQueryField(&queryField{name: "gpu.memory", kind: memoryField, gpu: true, bits: func(env *queryEnv) uint64 { return toBit(env.gpu.GetMemory()) }})
+
+	addQueryField(&queryField{name: "screen.size", kind: numberField, num: func(env *queryEnv) float64 { return float64(env.laptop.GetScreen().GetSizeInch()) }})
+	addQueryField(&queryField{name: "screen.width", kind: numberField, num: func(env *queryEnv) float64 { return float64(env.laptop.GetScreen().GetResolution().GetWidth()) }})
+	addQueryField(&queryField{name: "screen.height", kind: numberField, num: func(env *queryEnv) float64 { return float64(env.laptop.GetScreen().GetResolution().GetHeight()) }})
+	addQueryField(&queryField{name: "screen.panel", kind: enumField, enum: pb.Screen_Panel_value, value: func(env *queryEnv) int32 { return int32(env.laptop.GetScreen().GetPanel()) }}, "panel")
+	addQueryField(&queryField{name: "screen.multitouch", kind: boolField, flag: func(env *queryEnv) bool { return env.laptop.GetScreen().GetMultitouch() }}, "multitouch")
+
+	addQueryField(&queryField{name: "keyboard.layout", kind: enumField, enum: pb.Keyboard_Layout_value, value: func(env *queryEnv) int32 { return int32(env.laptop.GetKeyboard().GetLayout()) }}, "layout")
+	addQueryField(&queryField{name: "keyboard.backlit", kind: boolField, flag: func(env *queryEnv) bool { return env.laptop.GetKeyboard().GetBacklit() }}, "backlit")
+}
+
+func compare(name string, op compareOp, value queryValue) queryExpr {
+	return &compareExpr{field: queryFields[name], op: op, value: value}
+}
+
+func allOf(exprs []queryExpr) queryExpr {
+	if len(exprs) == 0 {
+		return &trueExpr{}
+	}
+
+	expr := exprs[0]
+	for _, other := range exprs[1:] {
+		expr = &andExpr{left: expr, right: other}
+	}
+	return expr
+}
+
+//CompileFilter compiles a filter into a query, criteria that are not set in the filter are left out
+func CompileFilter(filter *pb.Filter) *Query {
+	exprs := []queryExpr{}
+
+	if filter.GetMaxPriceUsd() > 0 {
+		exprs = append(exprs, compare("price", opLessEqual, queryValue{num: filter.GetMaxPriceUsd()}))
+	}
+	if filter.GetMinPriceUsd() > 0 {
+		exprs = append(exprs, compare("price", opGreaterEqual, queryValue{num: filter.GetMinPriceUsd()}))
+	}
+	if filter.GetMinCpuCores() > 0 {
+		exprs = append(exprs, compare("cpu.cores", opGreaterEqual, queryValue{num: float64(filter.GetMinCpuCores())}))
+	}
+	if filter.GetMinCpuGhz() > 0 {
+		exprs = append(exprs, compare("cpu.min_ghz", opGreaterEqual, queryValue{num: filter.GetMinCpuGhz()}))
+	}
+	if bits := toBit(filter.GetMinRam()); bits > 0 {
+		exprs = append(exprs, compare("ram", opGreaterEqual, queryValue{bits: bits}))
+	}
+
+	if filter.GetBrand() != "" {
+		exprs = append(exprs, compare("brand", opEqual, queryValue{str: filter.GetBrand()}))
+	}
+	if filter.GetName() != "" {
+		exprs = append(exprs, compare("name", opContains, queryValue{str: filter.GetName()}))
+	}
+
+	if filter.GetGpuBrand() != "" || filter.GetMinGpuMemory() != nil {
+		gpu := []queryExpr{}
+		if filter.GetGpuBrand() != "" {
+			gpu = append(gpu, compare("gpu.brand", opEqual, queryValue{str: filter.GetGpuBrand()}))
+		}
+		if bits := toBit(filter.GetMinGpuMemory()); bits > 0 {
+			gpu = append(gpu, compare("gpu.memory", opGreaterEqual, queryValue{bits: bits}))
+		}
+		exprs = append(exprs, &gpuExpr{expr: allOf(gpu)})
+	}
+
+	if bits := toBit(filter.GetMinSsd()); bits > 0 {
+		exprs = append(exprs, compare("ssd", opGreaterEqual, queryValue{bits: bits}))
+	}
+	if bits := toBit(filter.GetMinHdd()); bits > 0 {
+		exprs = append(exprs, compare("hdd", opGreaterEqual, queryValue{bits: bits}))
+	}
+
+	if filter.GetMinScreenSizeInch() > 0 {
+		exprs = append(exprs, compare("screen.size", opGreaterEqual, queryValue{num: float64(filter.GetMinScreenSizeInch())}))
+	}
+	if filter.GetMaxScreenSizeInch() > 0 {
+		exprs = append(exprs, compare("screen.size", opLessEqual, queryValue{num: float64(filter.GetMaxScreenSizeInch())}))
+	}
+	if filter.GetMinResolution().GetWidth() > 0 {
+		exprs = append(exprs, compare("screen.width", opGreaterEqual, queryValue{num: float64(filter.GetMinResolution().GetWidth())}))
+	}
+	if filter.GetMinResolution().GetHeight() > 0 {
+		exprs = append(exprs, compare("screen.height", opGreaterEqual, queryValue{num: float64(filter.GetMinResolution().GetHeight())}))
+	}
+	if filter.GetPanel() != pb.Screen_UNKNOWN {
+		exprs = append(exprs, compare("screen.panel", opEqual, queryValue{enum: int32(filter.GetPanel())}))
+	}
+	if filter.GetMultitouch() != nil {
+		exprs = append(exprs, compare("screen.multitouch", opEqual, queryValue{flag: filter.GetMultitouch().GetValue()}))
+	}
+
+	if filter.GetMinWeightKg() > 0 {
+		exprs = append(exprs, compare("weight", opGreaterEqual, queryValue{num: filter.GetMinWeightKg()}))
+	}
+	if filter.GetMaxWeightKg() > 0 {
+		exprs = append(exprs, compare("weight", opLessEqual, queryValue{num: filter.GetMaxWeightKg()}))
+	}
+
+	if filter.GetMinReleaseYear() > 0 {
+		exprs = append(exprs, compare("release_year", opGreaterEqual, queryValue{num: float64(filter.GetMinReleaseYear())}))
+	}
+	if filter.GetMaxReleaseYear() > 0 {
+		exprs = append(exprs, compare("release_year", opLessEqual, queryValue{num: float64(filter.GetMaxReleaseYear())}))
+	}
+
+	if filter.GetKeyboardLayout() != pb.Keyboard_UNKNOWN {
+		exprs = append(exprs, compare("keyboard.layout", opEqual, queryValue{enum: int32(filter.GetKeyboardLayout())}))
+	}
+	if filter.GetBacklit() != nil {
+		exprs = append(exprs, compare("keyboard.backlit", opEqual, queryValue{flag: filter.GetBacklit().GetValue()}))
+	}
+
+	return &Query{expr: allOf(exprs)}
+}
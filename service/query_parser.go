@@ -0,0 +1,381 @@
+package service
+
+import (
+	"demo-grpc/pb"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+//QueryError is returned when a query cannot be parsed, Position is the 1-based offset of the offending character
+type QueryError struct {
+	Position int
+	Message  string
+}
+
+func (err *QueryError) Error() string {
+	return fmt.Sprintf("Invalid query at position %d: %s", err.Position, err.Message)
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenLeftParen
+	tokenRightParen
+	tokenOperator
+	tokenNumber
+	tokenString
+	tokenIdent
+	tokenAnd
+	tokenOr
+	tokenNot
+)
+
+type queryToken struct {
+	kind tokenKind
+	text string
+	//unit is the memory unit written right after a number, like the GB of 16GB
+	unit string
+	pos  int
+}
+
+func (token queryToken) describe() string {
+	if token.kind == tokenEOF {
+		return "end of query"
+	}
+	return fmt.Sprintf("%q", token.text)
+}
+
+func queryErrorAt(pos int, format string, args ...interface{}) error {
+	return &QueryError{Position: pos + 1, Message: fmt.Sprintf(format, args...)}
+}
+
+func isIdentChar(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.' || r == '-'
+}
+
+func tokenizeQuery(text string) ([]queryToken, error) {
+	tokens := []queryToken{}
+	runes := []rune(text)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		start := i
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+			continue
+
+		case r == '(':
+			tokens = append(tokens, queryToken{kind: tokenLeftParen, text: "(", pos: start})
+			i++
+
+		case r == ')':
+			tokens = append(tokens, queryToken{kind: tokenRightParen, text: ")", pos: start})
+			i++
+
+		case strings.ContainsRune("=!<>~", r):
+			i++
+			if i < len(runes) && runes[i] == '=' {
+				i++
+			}
+			op := string(runes[start:i])
+			if _, ok := compareOps[op]; !ok {
+				return nil, queryErrorAt(start, "unknown operator %q", op)
+			}
+			tokens = append(tokens, queryToken{kind: tokenOperator, text: op, pos: start})
+
+		case r == '"' || r == '\'':
+			i++
+			for i < len(runes) && runes[i] != r {
+				i++
+			}
+			if i == len(runes) {
+				return nil, queryErrorAt(start, "unterminated string")
+			}
+			tokens = append(tokens, queryToken{kind: tokenString, text: string(runes[start+1 : i]), pos: start})
+			i++
+
+		case unicode.IsDigit(r) || r == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1]):
+			//a minus sign right before a digit makes a negative number rather than an identifier
+			if r == '-' {
+				i++
+			}
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			number := string(runes[start:i])
+			unitStart := i
+			for i < len(runes) && unicode.IsLetter(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, queryToken{kind: tokenNumber, text: number, unit: string(runes[unitStart:i]), pos: start})
+
+		case isIdentChar(r):
+			for i < len(runes) && isIdentChar(runes[i]) {
+				i++
+			}
+			word := string(runes[start:i])
+
+			kind := tokenIdent
+			switch strings.ToUpper(word) {
+			case "AND":
+				kind = tokenAnd
+			case "OR":
+				kind = tokenOr
+			case "NOT":
+				kind = tokenNot
+			}
+			tokens = append(tokens, queryToken{kind: kind, text: word, pos: start})
+
+		default:
+			return nil, queryErrorAt(start, "unexpected character %q", r)
+		}
+	}
+
+	tokens = append(tokens, queryToken{kind: tokenEOF, pos: len(runes)})
+	return tokens, nil
+}
+
+//queryParser is a recursive descent parser for the grammar:
+//
+//	or         = and { OR and }
+//	and        = not { AND not }
+//	not        = NOT not | primary
+//	primary    = "(" or ")" | comparison
+//	comparison = field [ operator value ]
+//
+//A field without an operator is only allowed for boolean fields, and means the field is true
+type queryParser struct {
+	tokens []queryToken
+	next   int
+}
+
+//ParseQuery parses a textual query such as
+//(brand = Apple OR brand = Dell) AND ram >= 16GB AND NOT panel = OLED
+func ParseQuery(text string) (*Query, error) {
+	tokens, err := tokenizeQuery(text)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := &queryParser{tokens: tokens}
+	expr, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if token := parser.peek(); token.kind != tokenEOF {
+		return nil, queryErrorAt(token.pos, "unexpected %s", token.describe())
+	}
+
+	return &Query{expr: expr}, nil
+}
+
+func (parser *queryParser) peek() queryToken {
+	return parser.tokens[parser.next]
+}
+
+func (parser *queryParser) advance() queryToken {
+	token := parser.tokens[parser.next]
+	if token.kind != tokenEOF {
+		parser.next++
+	}
+	return token
+}
+
+func (parser *queryParser) parseOr() (queryExpr, error) {
+	left, err := parser.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for parser.peek().kind == tokenOr {
+		parser.advance()
+		right, err := parser.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orExpr{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (parser *queryParser) parseAnd() (queryExpr, error) {
+	left, err := parser.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for parser.peek().kind == tokenAnd {
+		parser.advance()
+		right, err := parser.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &andExpr{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (parser *queryParser) parseNot() (queryExpr, error) {
+	if parser.peek().kind != tokenNot {
+		return parser.parsePrimary()
+	}
+
+	parser.advance()
+	expr, err := parser.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	return &notExpr{expr: expr}, nil
+}
+
+func (parser *queryParser) parsePrimary() (queryExpr, error) {
+	token := parser.advance()
+
+	switch token.kind {
+	case tokenLeftParen:
+		expr, err := parser.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := parser.advance(); closing.kind != tokenRightParen {
+			return nil, queryErrorAt(closing.pos, "expected \")\" but found %s", closing.describe())
+		}
+		return expr, nil
+
+	case tokenIdent:
+		return parser.parseComparison(token)
+
+	default:
+		return nil, queryErrorAt(token.pos, "expected a field name or \"(\" but found %s", token.describe())
+	}
+}
+
+func (parser *queryParser) parseComparison(name queryToken) (queryExpr, error) {
+	field := queryFields[strings.ToLower(name.text)]
+	if field == nil {
+		return nil, queryErrorAt(name.pos, "unknown field %q", name.text)
+	}
+
+	var expr queryExpr
+	if field.kind == boolField && parser.peek().kind != tokenOperator {
+		expr = &compareExpr{field: field, op: opEqual, value: queryValue{flag: true}}
+	} else {
+		opToken := parser.advance()
+		if opToken.kind != tokenOperator {
+			return nil, queryErrorAt(opToken.pos, "expected an operator after %q but found %s", name.text, opToken.describe())
+		}
+
+		op := compareOps[opToken.text]
+		if !fieldAllowsOp(field, op) {
+			return nil, queryErrorAt(opToken.pos, "operator %q cannot be used with %s field %q", opToken.text, field.kind, name.text)
+		}
+
+		value, err := parser.parseValue(field)
+		if err != nil {
+			return nil, err
+		}
+
+		expr = &compareExpr{field: field, op: op, value: value}
+	}
+
+	if field.gpu {
+		expr = &gpuExpr{expr: expr}
+	}
+	return expr, nil
+}
+
+func fieldAllowsOp(field *queryField, op compareOp) bool {
+	switch field.kind {
+	case stringField:
+		return op == opEqual || op == opNotEqual || op == opContains
+	case numberField, memoryField:
+		return op != opContains
+	default:
+		return op == opEqual || op == opNotEqual
+	}
+}
+
+func (parser *queryParser) parseValue(field *queryField) (queryValue, error) {
+	token := parser.advance()
+
+	switch field.kind {
+	case stringField:
+		if token.kind != tokenString && token.kind != tokenIdent && token.kind != tokenNumber {
+			return queryValue{}, queryErrorAt(token.pos, "expected a text value but found %s", token.describe())
+		}
+		return queryValue{str: token.text + token.unit}, nil
+
+	case numberField:
+		if token.kind != tokenNumber || token.unit != "" {
+			return queryValue{}, queryErrorAt(token.pos, "expected a number but found %s", token.describe())
+		}
+		number, err := strconv.ParseFloat(token.text, 64)
+		if err != nil {
+			return queryValue{}, queryErrorAt(token.pos, "invalid number %q", token.text)
+		}
+		return queryValue{num: number}, nil
+
+	case memoryField:
+		return parser.parseMemory(token)
+
+	case boolField:
+		value, err := strconv.ParseBool(strings.ToLower(token.text))
+		if token.kind != tokenIdent || err != nil {
+			return queryValue{}, queryErrorAt(token.pos, "expected true or false but found %s", token.describe())
+		}
+		return queryValue{flag: value}, nil
+
+	default:
+		value, ok := field.enum[strings.ToUpper(token.text)]
+		if token.kind != tokenIdent || !ok {
+			return queryValue{}, queryErrorAt(token.pos, "%s is not a valid value of %q", token.describe(), field.name)
+		}
+		return queryValue{enum: value}, nil
+	}
+}
+
+var memoryUnits = map[string]pb.Memory_Unit{
+	"BIT":   pb.Memory_BIT,
+	"BITS":  pb.Memory_BIT,
+	"B":     pb.Memory_BYTE,
+	"BYTE":  pb.Memory_BYTE,
+	"BYTES": pb.Memory_BYTE,
+	"KB":    pb.Memory_KILOBYTE,
+	"MB":    pb.Memory_MEGABYTE,
+	"GB":    pb.Memory_GIGABYTE,
+	"TB":    pb.Memory_TERABYTE,
+}
+
+//parseMemory parses a memory size like 16GB or 512 MB into bits
+func (parser *queryParser) parseMemory(token queryToken) (queryValue, error) {
+	if token.kind != tokenNumber {
+		return queryValue{}, queryErrorAt(token.pos, "expected a memory size like 16GB but found %s", token.describe())
+	}
+
+	value, err := strconv.ParseUint(token.text, 10, 64)
+	if err != nil {
+		return queryValue{}, queryErrorAt(token.pos, "memory size %q must be a whole number", token.text)
+	}
+
+	unit := token.unit
+	if unit == "" && parser.peek().kind == tokenIdent {
+		if _, ok := memoryUnits[strings.ToUpper(parser.peek().text)]; ok {
+			unit = parser.advance().text
+		}
+	}
+
+	memoryUnit, ok := memoryUnits[strings.ToUpper(unit)]
+	if !ok {
+		return queryValue{}, queryErrorAt(token.pos, "memory size %q needs a unit like GB", token.text+unit)
+	}
+
+	return queryValue{bits: toBit(&pb.Memory{Value: value, Unit: memoryUnit})}, nil
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"demo-grpc/pb"
+	"demo-grpc/sample"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuery(t *testing.T) {
+	t.Parallel()
+
+	laptop := sample.NewLaptop()
+	laptop.Brand = "Dell"
+	laptop.Name = "XPS"
+	laptop.PriceUsd = 1800
+	laptop.Ram = &pb.Memory{Value: 16384, Unit: pb.Memory_MEGABYTE}
+	laptop.Screen.Panel = pb.Screen_IPS
+	laptop.Keyboard.Backlit = true
+	laptop.Gpus = []*pb.GPU{
+		{Brand: "AMD", Name: "RX 580", Memory: &pb.Memory{Value: 4, Unit: pb.Memory_GIGABYTE}},
+		{Brand: "Nvidia", Name: "RTX 2070", Memory: &pb.Memory{Value: 8, Unit: pb.Memory_GIGABYTE}},
+	}
+
+	testCases := []struct {
+		query string
+		match bool
+	}{
+		{"(brand = Apple OR brand = Dell) AND ram >= 16GB AND NOT panel = OLED", true},
+		{"brand = apple or brand = lenovo", false},
+		{"ram > 16 GB", false},
+		{"ram = 2048 MB OR ram = 16384MB", true},
+		{"price < 2000 and price >= 1800", true},
+		{"price > -5 AND price >= -1800.5", true},
+		{"price < -5", false},
+		{"name ~ 'xp' AND brand != \"Apple\"", true},
+		{"backlit AND NOT multitouch = true OR multitouch", true},
+		{"not backlit", false},
+		{"gpu.brand = Nvidia AND gpu.memory >= 8GB", true},
+		{"gpu.memory > 8GB", false},
+		{"NOT (gpu.name ~ RX)", false},
+		{"layout = qwerty or layout = qwertz or layout = azerty", true},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.query, func(t *testing.T) {
+			query, err := ParseQuery(tc.query)
+			require.NoError(t, err)
+			require.Equal(t, tc.match, query.Match(laptop))
+		})
+	}
+}
+
+func TestParseQueryError(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		query    string
+		position int
+	}{
+		{"brand = Apple AND", 18},
+		{"(brand = Apple", 15},
+		{"brand Apple", 7},
+		{"colour = red", 1},
+		{"ram >= 16", 8},
+		{"price >= cheap", 10},
+		{"panel = LCD", 9},
+		{"price > 1 ) ", 11},
+		{"brand = 'Apple", 9},
+		{"price >= 1000 # 2000", 15},
+		{"price ~ 10", 7},
+		{"ram >= -16GB", 8},
+		{"price >= - 5", 10},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.query, func(t *testing.T) {
+			_, err := ParseQuery(tc.query)
+			require.Error(t, err)
+
+			var queryErr *QueryError
+			require.True(t, errors.As(err, &queryErr))
+			require.Equal(t, tc.position, queryErr.Position, err.Error())
+		})
+	}
+}
@@ -5,6 +5,9 @@ import "sync"
 //RatingStore is an interface to store laptop ratings
 type RatingStore interface {
 	Add(laptopID string, score float64) (*Rating, error)
+	Find(laptopID string) (*Rating, error)
+	Set(laptopID string, rating *Rating) error
+	Delete(laptopID string) error
 }
 
 //Rating contains the rating information of a laptop
@@ -45,3 +48,40 @@ func (store *InMemoryRatingStore) Add(laptopID string, score float64) (*Rating,
 	store.rating[laptopID] = rating
 	return rating, nil
 }
+
+//Find returns the rating of a laptop, or nil if it has never been rated
+func (store *InMemoryRatingStore) Find(laptopID string) (*Rating, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	rating := store.rating[laptopID]
+	if rating == nil {
+		return nil, nil
+	}
+
+	return &Rating{
+		Count: rating.Count,
+		Sum:   rating.Sum,
+	}, nil
+}
+
+//Set replaces the rating of a laptop
+func (store *InMemoryRatingStore) Set(laptopID string, rating *Rating) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.rating[laptopID] = &Rating{
+		Count: rating.Count,
+		Sum:   rating.Sum,
+	}
+	return nil
+}
+
+//Delete removes the rating of a laptop, if it has one
+func (store *InMemoryRatingStore) Delete(laptopID string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	delete(store.rating, laptopID)
+	return nil
+}
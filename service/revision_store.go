@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"demo-grpc/pb"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+)
+
+//RevisionStore is an interface to keep the append-only history of every laptop
+type RevisionStore interface {
+	//Append stores a revision and returns its number, which is one more than the last revision of the same laptop
+	Append(revision *pb.LaptopRevision) (uint64, error)
+	Find(laptopID string, revision uint64) (*pb.LaptopRevision, error)
+	List(laptopID string, afterRevision uint64, limit int) ([]*pb.LaptopRevision, error)
+}
+
+//InMemoryRevisionStore stores laptop revisions in memory
+type InMemoryRevisionStore struct {
+	mutex     sync.RWMutex
+	revisions map[string][]*pb.LaptopRevision
+}
+
+//NewInMemoryRevisionStore returns a new InMemoryRevisionStore
+func NewInMemoryRevisionStore() *InMemoryRevisionStore {
+	return &InMemoryRevisionStore{
+		revisions: make(map[string][]*pb.LaptopRevision),
+	}
+}
+
+//Append stores a copy of the revision and returns its number
+func (store *InMemoryRevisionStore) Append(revision *pb.LaptopRevision) (uint64, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	other := proto.Clone(revision).(*pb.LaptopRevision)
+	other.Revision = uint64(len(store.revisions[revision.GetLaptopId()])) + 1
+
+	store.revisions[revision.GetLaptopId()] = append(store.revisions[revision.GetLaptopId()], other)
+	return other.Revision, nil
+}
+
+//put stores a revision that is already numbered, which must be the next revision of its laptop
+func (store *InMemoryRevisionStore) put(revision *pb.LaptopRevision) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	laptopID := revision.GetLaptopId()
+	if revision.GetRevision() != uint64(len(store.revisions[laptopID]))+1 {
+		return fmt.Errorf("Revision %d of laptop %s is out of order", revision.GetRevision(), laptopID)
+	}
+
+	store.revisions[laptopID] = append(store.revisions[laptopID], revision)
+	return nil
+}
+
+//count returns the number of revisions of a laptop
+func (store *InMemoryRevisionStore) count(laptopID string) uint64 {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	return uint64(len(store.revisions[laptopID]))
+}
+
+//Find returns a revision of a laptop, or nil if there is no such revision
+func (store *InMemoryRevisionStore) Find(laptopID string, revision uint64) (*pb.LaptopRevision, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	revisions := store.revisions[laptopID]
+	if revision == 0 || revision > uint64(len(revisions)) {
+		return nil, nil
+	}
+
+	return proto.Clone(revisions[revision-1]).(*pb.LaptopRevision), nil
+}
+
+//List returns up to limit revisions of a laptop in ascending order, starting right after afterRevision
+func (store *InMemoryRevisionStore) List(laptopID string, afterRevision uint64, limit int) ([]*pb.LaptopRevision, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	revisions := store.revisions[laptopID]
+	result := []*pb.LaptopRevision{}
+
+	for i := afterRevision; i < uint64(len(revisions)) && len(result) < limit; i++ {
+		result = append(result, proto.Clone(revisions[i]).(*pb.LaptopRevision))
+	}
+	return result, nil
+}
+
+//newRevision returns a revision of a laptop made now by the user who made the call
+func newRevision(
+	ctx context.Context,
+	operation pb.LaptopRevision_Operation,
+	laptop *pb.Laptop,
+	version uint64,
+) (*pb.LaptopRevision, error) {
+	timestamp, err := ptypes.TimestampProto(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	revision := &pb.LaptopRevision{
+		LaptopId:  laptop.GetId(),
+		Operation: operation,
+		Timestamp: timestamp,
+		Laptop:    laptop,
+		Username:  callerName(ctx),
+		Version:   version,
+	}
+	return revision, nil
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"container/heap"
+	"demo-grpc/pb"
+	"sort"
+)
+
+//SearchOptions controls the order and the number of laptops returned by a search
+type SearchOptions struct {
+	SortBy     pb.SearchLaptopRequest_SortBy
+	Descending bool
+	MaxResults int
+	//Query must be matched by the laptops on top of the search filter
+	Query *Query
+	//Text restricts the search to the laptops matching every word of it, ranked by relevance unless SortBy is set
+	Text string
+	//Rating returns the average rating of a laptop, it is only used to sort by rating
+	Rating func(laptopID string) float64
+
+	relevance map[string]float64
+}
+
+func (options *SearchOptions) sorted() bool {
+	return options != nil && options.SortBy != pb.SearchLaptopRequest_UNSORTED
+}
+
+func (options *SearchOptions) query() *Query {
+	if options == nil {
+		return nil
+	}
+	return options.Query
+}
+
+func (options *SearchOptions) text() string {
+	if options == nil {
+		return ""
+	}
+	return options.Text
+}
+
+//withRelevance returns a copy of options that can sort by the given relevance scores of a text search
+func (options *SearchOptions) withRelevance(relevance map[string]float64) *SearchOptions {
+	other := *options
+	other.relevance = relevance
+	if other.SortBy == pb.SearchLaptopRequest_UNSORTED {
+		other.SortBy = pb.SearchLaptopRequest_RELEVANCE
+		other.Descending = true
+	}
+	return &other
+}
+
+func (options *SearchOptions) maxResults() int {
+	if options == nil {
+		return 0
+	}
+	return options.MaxResults
+}
+
+func (options *SearchOptions) sortKey(laptop *pb.Laptop) float64 {
+	switch options.SortBy {
+	case pb.SearchLaptopRequest_PRICE:
+		return laptop.GetPriceUsd()
+	case pb.SearchLaptopRequest_RELEASE_YEAR:
+		return float64(laptop.GetReleaseYear())
+	case pb.SearchLaptopRequest_CPU_CORES:
+		return float64(laptop.GetCpu().GetNumberCores())
+	case pb.SearchLaptopRequest_CPU_GHZ:
+		return laptop.GetCpu().GetMinGhz()
+	case pb.SearchLaptopRequest_RAM:
+		return float64(toBit(laptop.GetRam()))
+	case pb.SearchLaptopRequest_RATING:
+		if options.Rating == nil {
+			return 0
+		}
+		return options.Rating(laptop.GetId())
+	case pb.SearchLaptopRequest_UPDATED_AT:
+		updatedAt := laptop.GetUpdatedAt()
+		return float64(updatedAt.GetSeconds()) + float64(updatedAt.GetNanos())/1e9
+	case pb.SearchLaptopRequest_RELEVANCE:
+		return options.relevance[laptop.GetId()]
+	default:
+		return 0
+	}
+}
+
+type rankedLaptop struct {
+	laptop *pb.Laptop
+	key    float64
+}
+
+//rankedLaptops keeps laptops ordered by their sort key, the ID breaks ties so the order is deterministic
+type rankedLaptops struct {
+	items      []rankedLaptop
+	descending bool
+}
+
+func (ranked *rankedLaptops) Len() int { return len(ranked.items) }
+
+func (ranked *rankedLaptops) Less(i, j int) bool {
+	return ranked.before(ranked.items[i], ranked.items[j])
+}
+
+//before reports whether a comes before b in the results
+func (ranked *rankedLaptops) before(a, b rankedLaptop) bool {
+	if a.key != b.key {
+		return (a.key < b.key) != ranked.descending
+	}
+	return a.laptop.GetId() < b.laptop.GetId()
+}
+
+func (ranked *rankedLaptops) Swap(i, j int) {
+	ranked.items[i], ranked.items[j] = ranked.items[j], ranked.items[i]
+}
+
+//worstFirst is a heap whose root is the ranked laptop that would come last in the results
+type worstFirst struct{ *rankedLaptops }
+
+func (h worstFirst) Less(i, j int) bool { return h.rankedLaptops.Less(j, i) }
+
+func (h worstFirst) Push(x interface{}) {
+	h.items = append(h.items, x.(rankedLaptop))
+}
+
+func (h worstFirst) Pop() interface{} {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
+
+//sortedResults collects the laptops of a sorted search.
+//With a result limit, only the best laptops seen so far are kept in a bounded heap
+type sortedResults struct {
+	options *SearchOptions
+	ranked  *rankedLaptops
+}
+
+func newSortedResults(options *SearchOptions) *sortedResults {
+	return &sortedResults{
+		options: options,
+		ranked:  &rankedLaptops{descending: options.Descending},
+	}
+}
+
+func (results *sortedResults) add(laptop *pb.Laptop) {
+	item := rankedLaptop{laptop: laptop, key: results.options.sortKey(laptop)}
+	limit := results.options.maxResults()
+
+	if limit <= 0 {
+		results.ranked.items = append(results.ranked.items, item)
+		return
+	}
+
+	h := worstFirst{results.ranked}
+	if h.Len() < limit {
+		heap.Push(h, item)
+		return
+	}
+
+	if h.before(item, h.items[0]) {
+		h.items[0] = item
+		heap.Fix(h, 0)
+	}
+}
+
+//laptops returns the collected laptops in result order
+func (results *sortedResults) laptops() []*pb.Laptop {
+	sort.Sort(results.ranked)
+
+	laptops := make([]*pb.Laptop, len(results.ranked.items))
+	for i, item := range results.ranked.items {
+		laptops[i] = item.laptop
+	}
+	return laptops
+}
+
+//arrange keeps the laptops that match the text of options, and returns them in the order and number given by options.
+//It is meant for stores without a text index, which collect all laptops matching the query first
+func (options *SearchOptions) arrange(laptops []*pb.Laptop) []*pb.Laptop {
+	if options.text() != "" {
+		index := NewTextIndex()
+		for _, laptop := range laptops {
+			index.Add(laptop)
+		}
+
+		relevance := index.Search(options.text())
+		matches := make([]*pb.Laptop, 0, len(relevance))
+		for _, laptop := range laptops {
+			if _, ok := relevance[laptop.GetId()]; ok {
+				matches = append(matches, laptop)
+			}
+		}
+
+		laptops = matches
+		options = options.withRelevance(relevance)
+	}
+
+	if options.sorted() {
+		results := newSortedResults(options)
+		for _, laptop := range laptops {
+			results.add(laptop)
+		}
+		return results.laptops()
+	}
+
+	if limit := options.maxResults(); limit > 0 && len(laptops) > limit {
+		laptops = laptops[:limit]
+	}
+	return laptops
+}
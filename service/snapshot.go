@@ -0,0 +1,455 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"demo-grpc/pb"
+	"demo-grpc/serializer"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/uuid"
+)
+
+//snapshotExtension is appended to the name of a snapshot to get the name of its archive file
+const snapshotExtension = ".snapshot"
+
+//serverStores are the stores whose content makes up the state of the server
+type serverStores struct {
+	laptopStore LaptopStore
+	userStore   UserStore
+	ratingStore RatingStore
+	imageStore  ImageStore
+}
+
+//snapshot is the content of an archive.
+//The archive is a header record followed by one catalog entry per laptop and one record per user,
+//all written as SnapshotRecord messages in the checksummed record format of serializer
+type snapshot struct {
+	createdAt time.Time
+	entries   []*pb.CatalogEntry
+	users     []*User
+}
+
+//eachLaptop calls fn with every laptop of the store in ascending ID order
+func eachLaptop(ctx context.Context, store LaptopStore, fn func(laptop *pb.Laptop) error) error {
+	afterID := ""
+	for {
+		laptops, err := store.List(ctx, afterID, maxPageSize)
+		if err != nil {
+			return fmt.Errorf("Cannot list laptops: %v", err)
+		}
+
+		for _, laptop := range laptops {
+			err = fn(laptop)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(laptops) < maxPageSize {
+			return nil
+		}
+		afterID = laptops[len(laptops)-1].GetId()
+	}
+}
+
+//writeSnapshot writes the content of the stores to a new archive at path.
+//The archive is written to a temporary file first, so that path only ever holds a complete archive
+func writeSnapshot(ctx context.Context, path string, stores *serverStores, createdAt time.Time) (*pb.SnapshotResponse, error) {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot create snapshot folder: %v", err)
+	}
+
+	file, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp-")
+	if err != nil {
+		return nil, fmt.Errorf("Cannot create snapshot file: %v", err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	res, err := writeSnapshotRecords(ctx, file, stores, createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	err = file.Sync()
+	if err != nil {
+		return nil, fmt.Errorf("Cannot sync snapshot file: %v", err)
+	}
+
+	err = file.Close()
+	if err != nil {
+		return nil, fmt.Errorf("Cannot close snapshot file: %v", err)
+	}
+
+	err = os.Rename(file.Name(), path)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot rename snapshot file: %v", err)
+	}
+
+	return res, nil
+}
+
+func writeSnapshotRecords(ctx context.Context, file io.Writer, stores *serverStores, createdAt time.Time) (*pb.SnapshotResponse, error) {
+	timestamp, err := ptypes.TimestampProto(createdAt)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot convert timestamp: %v", err)
+	}
+
+	res := &pb.SnapshotResponse{CreatedAt: timestamp}
+	writer := bufio.NewWriter(file)
+	write := func(record *pb.SnapshotRecord) error {
+		err := serializer.WriteChecksummedRecord(writer, record)
+		if err != nil {
+			return fmt.Errorf("Cannot write snapshot file: %v", err)
+		}
+		return nil
+	}
+
+	err = write(&pb.SnapshotRecord{
+		Record: &pb.SnapshotRecord_Header{Header: &pb.SnapshotHeader{CreatedAt: timestamp}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = eachLaptop(ctx, stores.laptopStore, func(laptop *pb.Laptop) error {
+		entry, err := catalogEntry(laptop, stores.ratingStore, stores.imageStore)
+		if err != nil {
+			return err
+		}
+
+		res.Laptops++
+		return write(&pb.SnapshotRecord{Record: &pb.SnapshotRecord_Entry{Entry: entry}})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := stores.userStore.List()
+	if err != nil {
+		return nil, fmt.Errorf("Cannot list users: %v", err)
+	}
+
+	for _, user := range users {
+		res.Users++
+		err = write(&pb.SnapshotRecord{
+			Record: &pb.SnapshotRecord_User{User: &pb.SnapshotUser{
+				Username:       user.Username,
+				HashedPassword: user.HashedPassword,
+				Role:           user.Role,
+			}},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = writer.Flush()
+	if err != nil {
+		return nil, fmt.Errorf("Cannot write snapshot file: %v", err)
+	}
+
+	return res, nil
+}
+
+//readSnapshot reads and checks a whole archive, so that a restore never starts from a broken one
+func readSnapshot(path string) (*snapshot, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	snap := &snapshot{}
+	laptopIDs := make(map[string]bool)
+	imageIDs := make(map[string]bool)
+	usernames := make(map[string]bool)
+
+	for first := true; ; first = false {
+		record := &pb.SnapshotRecord{}
+		err := serializer.ReadChecksummedRecord(reader, record)
+		if err == io.EOF && !first {
+			return snap, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Cannot read snapshot file: %v", err)
+		}
+
+		if first != (record.GetHeader() != nil) {
+			return nil, fmt.Errorf("Snapshot file must start with exactly one header")
+		}
+
+		switch {
+		case record.GetHeader() != nil:
+			snap.createdAt, err = ptypes.Timestamp(record.GetHeader().GetCreatedAt())
+			if err != nil {
+				return nil, fmt.Errorf("Snapshot header has an invalid timestamp: %v", err)
+			}
+
+		case record.GetEntry() != nil:
+			laptopID := record.GetEntry().GetLaptop().GetId()
+			_, err = uuid.Parse(laptopID)
+			if err != nil {
+				return nil, fmt.Errorf("Snapshot laptop ID is not a valid UUID: %v", err)
+			}
+			if laptopIDs[laptopID] {
+				return nil, fmt.Errorf("Snapshot holds laptop %s more than once", laptopID)
+			}
+			laptopIDs[laptopID] = true
+
+			for _, image := range record.GetEntry().GetImages() {
+				_, err = catalogImageInfo(laptopID, image)
+				if err != nil {
+					return nil, fmt.Errorf("Snapshot holds an invalid image: %v", err)
+				}
+				if imageIDs[image.GetId()] {
+					return nil, fmt.Errorf("Snapshot holds image %s more than once", image.GetId())
+				}
+				imageIDs[image.GetId()] = true
+			}
+			snap.entries = append(snap.entries, record.GetEntry())
+
+		case record.GetUser() != nil:
+			user := record.GetUser()
+			if user.GetUsername() == "" || usernames[user.GetUsername()] {
+				return nil, fmt.Errorf("Snapshot holds an empty or repeated username: %q", user.GetUsername())
+			}
+			usernames[user.GetUsername()] = true
+			snap.users = append(snap.users, &User{
+				Username:       user.GetUsername(),
+				HashedPassword: user.GetHashedPassword(),
+				Role:           user.GetRole(),
+			})
+
+		default:
+			return nil, fmt.Errorf("Snapshot file holds an empty record")
+		}
+	}
+}
+
+//restoreSnapshot makes the stores hold the content of the snapshot.
+//Laptops, users, ratings and images that are not in the snapshot are deleted, the files of those images too
+func restoreSnapshot(ctx context.Context, stores *serverStores, snap *snapshot) error {
+	archived := make(map[string]bool, len(snap.entries))
+	for _, entry := range snap.entries {
+		archived[entry.GetLaptop().GetId()] = true
+	}
+
+	//laptops are collected before any is deleted, so that the listing does not skip any
+	stale := []string{}
+	err := eachLaptop(ctx, stores.laptopStore, func(laptop *pb.Laptop) error {
+		if !archived[laptop.GetId()] {
+			stale = append(stale, laptop.GetId())
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, laptopID := range stale {
+		err = stores.laptopStore.Delete(laptopID, 0)
+		if err != nil && err != ErrNotFound {
+			return fmt.Errorf("Cannot delete laptop: %v", err)
+		}
+
+		err = restoreLaptopExtras(stores, laptopID, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, entry := range snap.entries {
+		err = restoreEntry(stores, entry)
+		if err != nil {
+			return err
+		}
+	}
+
+	return restoreUsers(stores.userStore, snap.users)
+}
+
+func restoreEntry(stores *serverStores, entry *pb.CatalogEntry) error {
+	laptop := entry.GetLaptop()
+
+	existing, err := stores.laptopStore.Find(laptop.GetId())
+	if err != nil {
+		return fmt.Errorf("Cannot find laptop: %v", err)
+	}
+
+	if existing == nil {
+		err = stores.laptopStore.Save(laptop)
+	} else {
+		_, err = stores.laptopStore.Update(laptop, 0)
+	}
+	if err != nil {
+		return fmt.Errorf("Cannot restore laptop %s: %v", laptop.GetId(), err)
+	}
+
+	return restoreLaptopExtras(stores, laptop.GetId(), entry)
+}
+
+//restoreLaptopExtras makes the rating and images of a laptop those of the entry, a nil entry removes them
+func restoreLaptopExtras(stores *serverStores, laptopID string, entry *pb.CatalogEntry) error {
+	var err error
+	if entry.GetRatedCount() > 0 {
+		err = stores.ratingStore.Set(laptopID, &Rating{Count: entry.GetRatedCount(), Sum: entry.GetRatingSum()})
+	} else {
+		err = stores.ratingStore.Delete(laptopID)
+	}
+	if err != nil {
+		return fmt.Errorf("Cannot restore rating: %v", err)
+	}
+
+	archived := make(map[string]bool)
+	restored := make([]*ImageInfo, 0, len(entry.GetImages()))
+	for _, image := range entry.GetImages() {
+		info, err := catalogImageInfo(laptopID, image)
+		if err != nil {
+			return fmt.Errorf("Cannot restore image: %v", err)
+		}
+		err = checkImageOwner(stores.imageStore, info)
+		if err != nil {
+			return fmt.Errorf("Cannot restore image: %v", err)
+		}
+		archived[info.ID] = true
+		restored = append(restored, info)
+	}
+
+	images, err := stores.imageStore.List(laptopID)
+	if err != nil {
+		return fmt.Errorf("Cannot list images: %v", err)
+	}
+
+	for _, image := range images {
+		if archived[image.ID] {
+			continue
+		}
+
+		err = stores.imageStore.Delete(image.ID)
+		if err != nil && err != ErrNotFound {
+			return fmt.Errorf("Cannot delete image: %v", err)
+		}
+	}
+
+	for _, info := range restored {
+		err = restoreImageInfo(stores.imageStore, info)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//restoreImageInfo records the info of an archived image. An image whose file is gone since the snapshot is skipped,
+//so that a restore never brings back an image that cannot be downloaded, and so are the variants whose files are gone
+func restoreImageInfo(store ImageStore, info *ImageInfo) error {
+	err := store.SaveInfo(info)
+	if err != nil {
+		return fmt.Errorf("Cannot restore image info: %v", err)
+	}
+
+	//the store decides where the files of an image are, so they are checked once the info is recorded
+	placed, err := store.Find(info.ID)
+	if err != nil {
+		return fmt.Errorf("Cannot find restored image: %v", err)
+	}
+	if placed == nil {
+		return nil
+	}
+
+	exists, err := imageFileExists(placed.Path)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		log.Printf("Skip restoring image %s of laptop %s, its file is gone", info.ID, info.LaptopID)
+		err = store.Delete(info.ID)
+		if err != nil && err != ErrNotFound {
+			return fmt.Errorf("Cannot delete image: %v", err)
+		}
+		return nil
+	}
+
+	variants := placed.Variants[:0]
+	for _, variant := range placed.Variants {
+		exists, err = imageFileExists(variant.Path)
+		if err != nil {
+			return err
+		}
+		if exists {
+			variants = append(variants, variant)
+		} else {
+			log.Printf("Skip restoring variant %d of image %s, its file is gone", variant.MaxSide, info.ID)
+		}
+	}
+	if len(variants) == len(placed.Variants) {
+		return nil
+	}
+
+	placed.Variants = variants
+	err = store.SaveInfo(placed)
+	if err != nil {
+		return fmt.Errorf("Cannot restore image info: %v", err)
+	}
+	return nil
+}
+
+func imageFileExists(path string) (bool, error) {
+	_, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("Cannot read image file: %v", err)
+	}
+	return true, nil
+}
+
+func restoreUsers(store UserStore, users []*User) error {
+	archived := make(map[string]*User, len(users))
+	for _, user := range users {
+		archived[user.Username] = user
+	}
+
+	current, err := store.List()
+	if err != nil {
+		return fmt.Errorf("Cannot list users: %v", err)
+	}
+
+	for _, user := range current {
+		if archived[user.Username] != nil && *archived[user.Username] == *user {
+			delete(archived, user.Username)
+			continue
+		}
+
+		err = store.Delete(user.Username)
+		if err != nil && err != ErrNotFound {
+			return fmt.Errorf("Cannot delete user: %v", err)
+		}
+	}
+
+	for _, user := range users {
+		if archived[user.Username] == nil {
+			continue
+		}
+
+		err = store.Save(user.Clone())
+		if err != nil {
+			return fmt.Errorf("Cannot restore user %s: %v", user.Username, err)
+		}
+	}
+
+	return nil
+}
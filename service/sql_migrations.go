@@ -0,0 +1,139 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+//sqlMigrations upgrade the schema of a SQL laptop store one step at a time.
+//The version of a schema is the number of migrations applied to it, so new migrations are only ever appended
+var sqlMigrations = []string{
+	`CREATE TABLE laptops (
+		id TEXT NOT NULL PRIMARY KEY,
+		version INTEGER NOT NULL,
+		brand TEXT NOT NULL,
+		name TEXT NOT NULL,
+		cpu_brand TEXT,
+		cpu_name TEXT NOT NULL,
+		cpu_cores INTEGER NOT NULL,
+		cpu_threads INTEGER NOT NULL,
+		cpu_min_ghz REAL NOT NULL,
+		cpu_max_ghz REAL NOT NULL,
+		ram_value INTEGER NOT NULL,
+		ram_unit INTEGER,
+		ram_bits INTEGER NOT NULL,
+		screen_size REAL NOT NULL,
+		screen_width INTEGER NOT NULL,
+		screen_height INTEGER NOT NULL,
+		screen_has_resolution INTEGER NOT NULL,
+		screen_panel INTEGER,
+		screen_multitouch INTEGER NOT NULL,
+		keyboard_layout INTEGER,
+		keyboard_backlit INTEGER NOT NULL,
+		weight_kg REAL,
+		weight_lb REAL,
+		weight REAL NOT NULL,
+		price_usd REAL NOT NULL,
+		release_year INTEGER NOT NULL,
+		updated_at_seconds INTEGER,
+		updated_at_nanos INTEGER NOT NULL
+	);
+	CREATE INDEX laptops_brand ON laptops (brand COLLATE NOCASE);
+	CREATE INDEX laptops_price ON laptops (price_usd);
+	CREATE INDEX laptops_release_year ON laptops (release_year);
+	CREATE INDEX laptops_ram ON laptops (ram_bits);
+	CREATE INDEX laptops_cpu_cores ON laptops (cpu_cores);
+	CREATE INDEX laptops_weight ON laptops (weight);
+	CREATE INDEX laptops_screen_size ON laptops (screen_size);`,
+
+	`CREATE TABLE gpus (
+		laptop_id TEXT NOT NULL REFERENCES laptops (id) ON DELETE CASCADE,
+		position INTEGER NOT NULL,
+		brand TEXT NOT NULL,
+		name TEXT NOT NULL,
+		min_ghz REAL NOT NULL,
+		max_ghz REAL NOT NULL,
+		memory_value INTEGER NOT NULL,
+		memory_unit INTEGER,
+		memory_bits INTEGER NOT NULL,
+		PRIMARY KEY (laptop_id, position)
+	);
+	CREATE INDEX gpus_brand ON gpus (brand COLLATE NOCASE, memory_bits);`,
+
+	`CREATE TABLE storages (
+		laptop_id TEXT NOT NULL REFERENCES laptops (id) ON DELETE CASCADE,
+		position INTEGER NOT NULL,
+		driver INTEGER NOT NULL,
+		memory_value INTEGER NOT NULL,
+		memory_unit INTEGER,
+		memory_bits INTEGER NOT NULL,
+		PRIMARY KEY (laptop_id, position)
+	);
+	CREATE INDEX storages_driver ON storages (laptop_id, driver);`,
+
+	//the history outlives the laptops, so it has no foreign key to them
+	`CREATE TABLE revisions (
+		laptop_id TEXT NOT NULL,
+		revision INTEGER NOT NULL,
+		data BLOB NOT NULL,
+		PRIMARY KEY (laptop_id, revision)
+	);`,
+
+	`CREATE TABLE trash (
+		id TEXT NOT NULL PRIMARY KEY,
+		data BLOB NOT NULL
+	);`,
+
+	//the last version of each deleted laptop, which a laptop saved again with the same ID carries on from
+	`CREATE TABLE retired_laptops (
+		id TEXT NOT NULL PRIMARY KEY,
+		version INTEGER NOT NULL
+	);`,
+}
+
+//MigrateSQL upgrades the schema of a SQL laptop store to the latest version, each migration in its own transaction
+func MigrateSQL(db *sql.DB) error {
+	_, err := db.Exec("CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER NOT NULL PRIMARY KEY)")
+	if err != nil {
+		return fmt.Errorf("Cannot create migrations table: %v", err)
+	}
+
+	var version int
+	err = db.QueryRow("SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&version)
+	if err != nil {
+		return fmt.Errorf("Cannot read schema version: %v", err)
+	}
+
+	if version > len(sqlMigrations) {
+		return fmt.Errorf("Schema version %d is newer than this server, which knows %d", version, len(sqlMigrations))
+	}
+
+	for ; version < len(sqlMigrations); version++ {
+		err = runMigration(db, version+1, sqlMigrations[version])
+		if err != nil {
+			return fmt.Errorf("Cannot migrate schema to version %d: %v", version+1, err)
+		}
+	}
+
+	return nil
+}
+
+func runMigration(db *sql.DB, version int, migration string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(migration)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("INSERT INTO schema_migrations (version) VALUES (?)", version)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,939 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"demo-grpc/pb"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	//registers the sqlite3 driver with database/sql
+	_ "github.com/mattn/go-sqlite3"
+)
+
+//SQLLaptopStore stores laptops in a SQL database, with their GPUs and storages in tables of their own.
+//Search filters are translated into SQL, so the database indexes do the filtering
+type SQLLaptopStore struct {
+	db *sql.DB
+}
+
+//OpenSQLiteDB opens the SQLite database file at path, creating it if needed,
+//and upgrades its schema to the latest version. The SQL stores share the database it returns
+func OpenSQLiteDB(path string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?_foreign_keys=on&_busy_timeout=5000", path))
+	if err != nil {
+		return nil, fmt.Errorf("Cannot open sqlite database: %v", err)
+	}
+
+	//SQLite has a single writer, sharing one connection avoids busy errors between our own transactions
+	db.SetMaxOpenConns(1)
+
+	err = MigrateSQL(db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+//NewSQLLaptopStore returns a new SQLLaptopStore using a database opened with OpenSQLiteDB
+func NewSQLLaptopStore(db *sql.DB) *SQLLaptopStore {
+	return &SQLLaptopStore{
+		db: db,
+	}
+}
+
+//Close closes the database of the store
+func (store *SQLLaptopStore) Close() error {
+	return store.db.Close()
+}
+
+//Save saves the laptop to store
+func (store *SQLLaptopStore) Save(laptop *pb.Laptop) error {
+	return store.write(func(tx *sql.Tx) error {
+		return insertLaptop(tx, laptop)
+	})
+}
+
+//SaveAll saves every laptop in one transaction, or none of them if any cannot be saved
+func (store *SQLLaptopStore) SaveAll(laptops []*pb.Laptop) error {
+	return store.write(func(tx *sql.Tx) error {
+		for _, laptop := range laptops {
+			err := insertLaptop(tx, laptop)
+			if err != nil {
+				return fmt.Errorf("Cannot save laptop %s: %w", laptop.Id, err)
+			}
+		}
+		return nil
+	})
+}
+
+func insertLaptop(tx *sql.Tx, laptop *pb.Laptop) error {
+	_, err := findVersion(tx, laptop.Id)
+	if err == nil {
+		return ErrAlreadyExists
+	}
+	if err != ErrNotFound {
+		return err
+	}
+
+	version := firstVersion
+	err = tx.QueryRow("SELECT version + 1 FROM retired_laptops WHERE id = ?", laptop.Id).Scan(&version)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	_, err = tx.Exec("DELETE FROM retired_laptops WHERE id = ?", laptop.Id)
+	if err != nil {
+		return err
+	}
+
+	args := append([]interface{}{laptop.Id, version}, laptopRow(laptop)...)
+	_, err = tx.Exec(
+		fmt.Sprintf("INSERT INTO laptops (id, version, %s) VALUES (?, ?%s)", laptopRowColumns, strings.Repeat(", ?", len(args)-2)),
+		args...,
+	)
+	if err != nil {
+		return err
+	}
+	return insertParts(tx, laptop)
+}
+
+//Find finds a laptop by ID
+func (store *SQLLaptopStore) Find(id string) (*pb.Laptop, error) {
+	laptop, _, err := store.FindWithVersion(id)
+	return laptop, err
+}
+
+//FindWithVersion finds a laptop by ID and returns it together with its current version
+func (store *SQLLaptopStore) FindWithVersion(id string) (*pb.Laptop, uint64, error) {
+	laptops, versions, err := store.query(context.Background(), "id = ?", []interface{}{id}, "", 0)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if len(laptops) == 0 {
+		return nil, 0, nil
+	}
+	return laptops[0], versions[0], nil
+}
+
+//Update replaces the stored laptop that has the same ID and returns its new version.
+//If expectedVersion is not zero, it must match the stored version
+func (store *SQLLaptopStore) Update(laptop *pb.Laptop, expectedVersion uint64) (uint64, error) {
+	var version uint64
+
+	err := store.write(func(tx *sql.Tx) error {
+		current, err := findVersion(tx, laptop.Id)
+		if err != nil {
+			return err
+		}
+
+		if expectedVersion != 0 && current != expectedVersion {
+			return ErrVersionMismatch
+		}
+		version = current + 1
+
+		args := append([]interface{}{version}, laptopRow(laptop)...)
+		args = append(args, laptop.Id)
+		_, err = tx.Exec(
+			fmt.Sprintf("UPDATE laptops SET version = ?, %s = ? WHERE id = ?", strings.ReplaceAll(laptopRowColumns, ", ", " = ?, ")),
+			args...,
+		)
+		if err != nil {
+			return err
+		}
+
+		err = deleteParts(tx, laptop.Id)
+		if err != nil {
+			return err
+		}
+		return insertParts(tx, laptop)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+//Delete removes a laptop by ID. If expectedVersion is not zero, it must match the stored version
+func (store *SQLLaptopStore) Delete(id string, expectedVersion uint64) error {
+	return store.write(func(tx *sql.Tx) error {
+		current, err := findVersion(tx, id)
+		if err != nil {
+			return err
+		}
+
+		if expectedVersion != 0 && current != expectedVersion {
+			return ErrVersionMismatch
+		}
+
+		_, err = tx.Exec("INSERT OR REPLACE INTO retired_laptops (id, version) VALUES (?, ?)", id, current)
+		if err != nil {
+			return err
+		}
+
+		err = deleteParts(tx, id)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec("DELETE FROM laptops WHERE id = ?", id)
+		return err
+	})
+}
+
+//List returns up to limit laptops in ascending ID order, starting right after afterID.
+//An empty afterID starts from the beginning of the store
+func (store *SQLLaptopStore) List(ctx context.Context, afterID string, limit int) ([]*pb.Laptop, error) {
+	laptops, _, err := store.query(ctx, "id > ?", []interface{}{afterID}, "id", limit)
+	return laptops, err
+}
+
+//Search searches for laptop with filter and the query of options, returns one by one via the found function.
+//Laptops are returned in the order given by options, and no more than its MaxResults if that is set.
+//The filter, the order and the limit are done by the database, unless options has a query or text to match
+func (store *SQLLaptopStore) Search(
+	ctx context.Context,
+	filter *pb.Filter,
+	options *SearchOptions,
+	found func(laptop *pb.Laptop) error,
+) error {
+	where, args := sqlFilter(filter)
+	order, ordered := sqlOrder(options)
+	inSQL := ordered && options.query() == nil && options.text() == ""
+
+	limit := 0
+	if inSQL {
+		limit = options.maxResults()
+	} else {
+		order = "id"
+	}
+
+	laptops, _, err := store.query(ctx, where, args, order, limit)
+	if err != nil {
+		return err
+	}
+
+	if !inSQL {
+		query := options.query()
+		matches := make([]*pb.Laptop, 0, len(laptops))
+		for _, laptop := range laptops {
+			if query.Match(laptop) {
+				matches = append(matches, laptop)
+			}
+		}
+		laptops = options.arrange(matches)
+	}
+
+	for _, laptop := range laptops {
+		err := found(laptop)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//write runs fn in a transaction, which is committed if fn returns no error and rolled back otherwise
+func (store *SQLLaptopStore) write(fn func(tx *sql.Tx) error) error {
+	tx, err := store.db.Begin()
+	if err != nil {
+		return fmt.Errorf("Cannot begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	err = fn(tx)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+//query returns the laptops matching the where clause with their versions, ordered by order and no more than limit
+//if it is positive. The laptops and their parts are read in one transaction, so they are consistent
+func (store *SQLLaptopStore) query(
+	ctx context.Context,
+	where string,
+	args []interface{},
+	order string,
+	limit int,
+) ([]*pb.Laptop, []uint64, error) {
+	statement := fmt.Sprintf("SELECT id, version, %s FROM laptops WHERE %s", laptopRowColumns, where)
+	if order != "" {
+		statement += " ORDER BY " + order
+	}
+	if limit > 0 {
+		statement += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	tx, err := store.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	laptops := []*pb.Laptop{}
+	versions := []uint64{}
+	byID := make(map[string]*pb.Laptop)
+
+	err = eachRow(ctx, tx, statement, args, func(rows *sql.Rows) error {
+		laptop, version, err := scanLaptop(rows)
+		if err != nil {
+			return err
+		}
+		laptops = append(laptops, laptop)
+		versions = append(versions, version)
+		byID[laptop.Id] = laptop
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = loadParts(ctx, tx, byID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return laptops, versions, nil
+}
+
+func findVersion(tx *sql.Tx, id string) (uint64, error) {
+	var version uint64
+	err := tx.QueryRow("SELECT version FROM laptops WHERE id = ?", id).Scan(&version)
+	if err == sql.ErrNoRows {
+		return 0, ErrNotFound
+	}
+	return version, err
+}
+
+//laptopRowColumns are the columns of the laptops table filled by laptopRow and read by scanLaptop
+const laptopRowColumns = "brand, name, cpu_brand, cpu_name, cpu_cores, cpu_threads, cpu_min_ghz, cpu_max_ghz, " +
+	"ram_value, ram_unit, ram_bits, screen_size, screen_width, screen_height, screen_has_resolution, screen_panel, " +
+	"screen_multitouch, keyboard_layout, keyboard_backlit, weight_kg, weight_lb, weight, price_usd, release_year, " +
+	"updated_at_seconds, updated_at_nanos"
+
+//laptopRow returns the values of laptopRowColumns for the laptop.
+//A nullable column is NULL when the message it belongs to is not set, so that reading it back gives the same laptop
+func laptopRow(laptop *pb.Laptop) []interface{} {
+	var cpuBrand, ramUnit, screenPanel, keyboardLayout, kg, lb, updatedAtSeconds interface{}
+
+	if cpu := laptop.GetCpu(); cpu != nil {
+		cpuBrand = cpu.GetBrand()
+	}
+	if ram := laptop.GetRam(); ram != nil {
+		ramUnit = int32(ram.GetUnit())
+	}
+	if screen := laptop.GetScreen(); screen != nil {
+		screenPanel = int32(screen.GetPanel())
+	}
+	if keyboard := laptop.GetKeyboard(); keyboard != nil {
+		keyboardLayout = int32(keyboard.GetLayout())
+	}
+	switch laptop.GetWeight().(type) {
+	case *pb.Laptop_WeightKg:
+		kg = laptop.GetWeightKg()
+	case *pb.Laptop_WeightLb:
+		lb = laptop.GetWeightLb()
+	}
+	if updatedAt := laptop.GetUpdatedAt(); updatedAt != nil {
+		updatedAtSeconds = updatedAt.GetSeconds()
+	}
+
+	return []interface{}{
+		laptop.GetBrand(),
+		laptop.GetName(),
+		cpuBrand,
+		laptop.GetCpu().GetName(),
+		laptop.GetCpu().GetNumberCores(),
+		laptop.GetCpu().GetNumberThreads(),
+		laptop.GetCpu().GetMinGhz(),
+		laptop.GetCpu().GetMaxGhz(),
+		int64(laptop.GetRam().GetValue()),
+		ramUnit,
+		int64(toBit(laptop.GetRam())),
+		float64(laptop.GetScreen().GetSizeInch()),
+		laptop.GetScreen().GetResolution().GetWidth(),
+		laptop.GetScreen().GetResolution().GetHeight(),
+		laptop.GetScreen().GetResolution() != nil,
+		screenPanel,
+		laptop.GetScreen().GetMultitouch(),
+		keyboardLayout,
+		laptop.GetKeyboard().GetBacklit(),
+		kg,
+		lb,
+		weightKg(laptop),
+		laptop.GetPriceUsd(),
+		laptop.GetReleaseYear(),
+		updatedAtSeconds,
+		laptop.GetUpdatedAt().GetNanos(),
+	}
+}
+
+//scanLaptop reads a laptop without its GPUs and storages from the id, version and laptopRowColumns of a row
+func scanLaptop(rows *sql.Rows) (*pb.Laptop, uint64, error) {
+	laptop := &pb.Laptop{}
+	cpu := &pb.CPU{}
+	ram := &pb.Memory{}
+	screen := &pb.Screen{Resolution: &pb.Screen_Resolution{}}
+	keyboard := &pb.Keyboard{}
+	updatedAt := &timestamp.Timestamp{}
+
+	var version uint64
+	var cpuBrand sql.NullString
+	var ramUnit, screenPanel, keyboardLayout, updatedAtSeconds sql.NullInt64
+	var weightKg, weightLb sql.NullFloat64
+	var ramBits int64
+	var screenSize, weight float64
+	var hasResolution bool
+
+	err := rows.Scan(
+		&laptop.Id,
+		&version,
+		&laptop.Brand,
+		&laptop.Name,
+		&cpuBrand,
+		&cpu.Name,
+		&cpu.NumberCores,
+		&cpu.NumberThreads,
+		&cpu.MinGhz,
+		&cpu.MaxGhz,
+		&ram.Value,
+		&ramUnit,
+		&ramBits,
+		&screenSize,
+		&screen.Resolution.Width,
+		&screen.Resolution.Height,
+		&hasResolution,
+		&screenPanel,
+		&screen.Multitouch,
+		&keyboardLayout,
+		&keyboard.Backlit,
+		&weightKg,
+		&weightLb,
+		&weight,
+		&laptop.PriceUsd,
+		&laptop.ReleaseYear,
+		&updatedAtSeconds,
+		&updatedAt.Nanos,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("Cannot read laptop: %v", err)
+	}
+
+	if cpuBrand.Valid {
+		cpu.Brand = cpuBrand.String
+		laptop.Cpu = cpu
+	}
+	if ramUnit.Valid {
+		ram.Unit = pb.Memory_Unit(ramUnit.Int64)
+		laptop.Ram = ram
+	}
+	if screenPanel.Valid {
+		screen.SizeInch = float32(screenSize)
+		screen.Panel = pb.Screen_Panel(screenPanel.Int64)
+		if !hasResolution {
+			screen.Resolution = nil
+		}
+		laptop.Screen = screen
+	}
+	if keyboardLayout.Valid {
+		keyboard.Layout = pb.Keyboard_Layout(keyboardLayout.Int64)
+		laptop.Keyboard = keyboard
+	}
+	if weightKg.Valid {
+		laptop.Weight = &pb.Laptop_WeightKg{WeightKg: weightKg.Float64}
+	} else if weightLb.Valid {
+		laptop.Weight = &pb.Laptop_WeightLb{WeightLb: weightLb.Float64}
+	}
+	if updatedAtSeconds.Valid {
+		updatedAt.Seconds = updatedAtSeconds.Int64
+		laptop.UpdatedAt = updatedAt
+	}
+
+	return laptop, version, nil
+}
+
+func insertParts(tx *sql.Tx, laptop *pb.Laptop) error {
+	for i, gpu := range laptop.GetGpus() {
+		_, err := tx.Exec(
+			"INSERT INTO gpus (laptop_id, position, brand, name, min_ghz, max_ghz, memory_value, memory_unit, memory_bits) "+
+				"VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+			laptop.Id, i, gpu.GetBrand(), gpu.GetName(), gpu.GetMinGhz(), gpu.GetMaxGhz(),
+			int64(gpu.GetMemory().GetValue()), memoryUnit(gpu.GetMemory()), int64(toBit(gpu.GetMemory())),
+		)
+		if err != nil {
+			return fmt.Errorf("Cannot insert GPU: %v", err)
+		}
+	}
+
+	for i, storage := range laptop.GetStorages() {
+		_, err := tx.Exec(
+			"INSERT INTO storages (laptop_id, position, driver, memory_value, memory_unit, memory_bits) VALUES (?, ?, ?, ?, ?, ?)",
+			laptop.Id, i, int32(storage.GetDriver()),
+			int64(storage.GetMemory().GetValue()), memoryUnit(storage.GetMemory()), int64(toBit(storage.GetMemory())),
+		)
+		if err != nil {
+			return fmt.Errorf("Cannot insert storage: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func deleteParts(tx *sql.Tx, laptopID string) error {
+	_, err := tx.Exec("DELETE FROM gpus WHERE laptop_id = ?", laptopID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec("DELETE FROM storages WHERE laptop_id = ?", laptopID)
+	return err
+}
+
+//memoryUnit returns the unit of memory for a nullable column, NULL if memory is not set
+func memoryUnit(memory *pb.Memory) interface{} {
+	if memory == nil {
+		return nil
+	}
+	return int32(memory.GetUnit())
+}
+
+func scanMemory(value int64, unit sql.NullInt64) *pb.Memory {
+	if !unit.Valid {
+		return nil
+	}
+	return &pb.Memory{Value: uint64(value), Unit: pb.Memory_Unit(unit.Int64)}
+}
+
+//sqlBatchSize is the number of laptop IDs read at once by loadParts, below the SQLite limit on query parameters
+const sqlBatchSize = 500
+
+//loadParts reads the GPUs and storages of the laptops, which are keyed by ID
+func loadParts(ctx context.Context, tx *sql.Tx, laptops map[string]*pb.Laptop) error {
+	ids := make([]interface{}, 0, len(laptops))
+	for id := range laptops {
+		ids = append(ids, id)
+	}
+
+	for len(ids) > 0 {
+		batch := ids
+		if len(batch) > sqlBatchSize {
+			batch = batch[:sqlBatchSize]
+		}
+		ids = ids[len(batch):]
+
+		in := strings.TrimPrefix(strings.Repeat(", ?", len(batch)), ", ")
+
+		err := eachRow(ctx, tx, fmt.Sprintf(
+			"SELECT laptop_id, brand, name, min_ghz, max_ghz, memory_value, memory_unit FROM gpus "+
+				"WHERE laptop_id IN (%s) ORDER BY laptop_id, position", in,
+		), batch, func(rows *sql.Rows) error {
+			var laptopID string
+			var memoryValue int64
+			var memoryUnit sql.NullInt64
+			gpu := &pb.GPU{}
+
+			err := rows.Scan(&laptopID, &gpu.Brand, &gpu.Name, &gpu.MinGhz, &gpu.MaxGhz, &memoryValue, &memoryUnit)
+			if err != nil {
+				return fmt.Errorf("Cannot read GPU: %v", err)
+			}
+
+			gpu.Memory = scanMemory(memoryValue, memoryUnit)
+			laptop := laptops[laptopID]
+			laptop.Gpus = append(laptop.Gpus, gpu)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		err = eachRow(ctx, tx, fmt.Sprintf(
+			"SELECT laptop_id, driver, memory_value, memory_unit FROM storages "+
+				"WHERE laptop_id IN (%s) ORDER BY laptop_id, position", in,
+		), batch, func(rows *sql.Rows) error {
+			var laptopID string
+			var driver int32
+			var memoryValue int64
+			var memoryUnit sql.NullInt64
+
+			err := rows.Scan(&laptopID, &driver, &memoryValue, &memoryUnit)
+			if err != nil {
+				return fmt.Errorf("Cannot read storage: %v", err)
+			}
+
+			laptop := laptops[laptopID]
+			laptop.Storages = append(laptop.Storages, &pb.Storage{
+				Driver: pb.Storage_Driver(driver),
+				Memory: scanMemory(memoryValue, memoryUnit),
+			})
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func eachRow(ctx context.Context, tx *sql.Tx, statement string, args []interface{}, fn func(rows *sql.Rows) error) error {
+	rows, err := tx.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := fn(rows)
+		if err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+//sqlFilter translates a filter into a WHERE clause over the laptops table and its arguments,
+//with the same semantics as CompileFilter. Criteria that are not set in the filter are left out
+func sqlFilter(filter *pb.Filter) (string, []interface{}) {
+	clauses := []string{}
+	args := []interface{}{}
+
+	add := func(clause string, values ...interface{}) {
+		clauses = append(clauses, clause)
+		args = append(args, values...)
+	}
+
+	if filter.GetMaxPriceUsd() > 0 {
+		add("price_usd <= ?", filter.GetMaxPriceUsd())
+	}
+	if filter.GetMinPriceUsd() > 0 {
+		add("price_usd >= ?", filter.GetMinPriceUsd())
+	}
+	if filter.GetMinCpuCores() > 0 {
+		add("cpu_cores >= ?", filter.GetMinCpuCores())
+	}
+	if filter.GetMinCpuGhz() > 0 {
+		add("cpu_min_ghz >= ?", filter.GetMinCpuGhz())
+	}
+	if bits := toBit(filter.GetMinRam()); bits > 0 {
+		add("ram_bits >= ?", int64(bits))
+	}
+
+	if filter.GetBrand() != "" {
+		add("brand = ? COLLATE NOCASE", filter.GetBrand())
+	}
+	if filter.GetName() != "" {
+		add("instr(lower(name), lower(?)) > 0", filter.GetName())
+	}
+
+	if filter.GetGpuBrand() != "" || filter.GetMinGpuMemory() != nil {
+		gpu := []string{"gpus.laptop_id = laptops.id"}
+		if filter.GetGpuBrand() != "" {
+			gpu = append(gpu, "gpus.brand = ? COLLATE NOCASE")
+			args = append(args, filter.GetGpuBrand())
+		}
+		if bits := toBit(filter.GetMinGpuMemory()); bits > 0 {
+			gpu = append(gpu, "gpus.memory_bits >= ?")
+			args = append(args, int64(bits))
+		}
+		clauses = append(clauses, fmt.Sprintf("EXISTS (SELECT 1 FROM gpus WHERE %s)", strings.Join(gpu, " AND ")))
+	}
+
+	storage := "(SELECT COALESCE(SUM(memory_bits), 0) FROM storages WHERE storages.laptop_id = laptops.id AND driver = ?) >= ?"
+	if bits := toBit(filter.GetMinSsd()); bits > 0 {
+		add(storage, int32(pb.Storage_SSD), int64(bits))
+	}
+	if bits := toBit(filter.GetMinHdd()); bits > 0 {
+		add(storage, int32(pb.Storage_HDD), int64(bits))
+	}
+
+	if filter.GetMinScreenSizeInch() > 0 {
+		add("screen_size >= ?", float64(filter.GetMinScreenSizeInch()))
+	}
+	if filter.GetMaxScreenSizeInch() > 0 {
+		add("screen_size <= ?", float64(filter.GetMaxScreenSizeInch()))
+	}
+	if filter.GetMinResolution().GetWidth() > 0 {
+		add("screen_width >= ?", filter.GetMinResolution().GetWidth())
+	}
+	if filter.GetMinResolution().GetHeight() > 0 {
+		add("screen_height >= ?", filter.GetMinResolution().GetHeight())
+	}
+	if filter.GetPanel() != pb.Screen_UNKNOWN {
+		add("screen_panel = ?", int32(filter.GetPanel()))
+	}
+	if filter.GetMultitouch() != nil {
+		add("screen_multitouch = ?", filter.GetMultitouch().GetValue())
+	}
+
+	if filter.GetMinWeightKg() > 0 {
+		add("weight >= ?", filter.GetMinWeightKg())
+	}
+	if filter.GetMaxWeightKg() > 0 {
+		add("weight <= ?", filter.GetMaxWeightKg())
+	}
+
+	if filter.GetMinReleaseYear() > 0 {
+		add("release_year >= ?", filter.GetMinReleaseYear())
+	}
+	if filter.GetMaxReleaseYear() > 0 {
+		add("release_year <= ?", filter.GetMaxReleaseYear())
+	}
+
+	if filter.GetKeyboardLayout() != pb.Keyboard_UNKNOWN {
+		add("keyboard_layout = ?", int32(filter.GetKeyboardLayout()))
+	}
+	if filter.GetBacklit() != nil {
+		add("keyboard_backlit = ?", filter.GetBacklit().GetValue())
+	}
+
+	if len(clauses) == 0 {
+		return "1", args
+	}
+	return strings.Join(clauses, " AND "), args
+}
+
+//sqlSortColumns are the columns that sort the laptops like the sort keys of SearchOptions
+var sqlSortColumns = map[pb.SearchLaptopRequest_SortBy][]string{
+	pb.SearchLaptopRequest_PRICE:        {"price_usd"},
+	pb.SearchLaptopRequest_RELEASE_YEAR: {"release_year"},
+	pb.SearchLaptopRequest_CPU_CORES:    {"cpu_cores"},
+	pb.SearchLaptopRequest_CPU_GHZ:      {"cpu_min_ghz"},
+	pb.SearchLaptopRequest_RAM:          {"ram_bits"},
+	pb.SearchLaptopRequest_UPDATED_AT:   {"COALESCE(updated_at_seconds, 0)", "updated_at_nanos"},
+}
+
+//sqlOrder returns the ORDER BY clause that sorts laptops as options do, with ties broken by ID.
+//It returns false if the sort key is not a column, like the rating or the relevance
+func sqlOrder(options *SearchOptions) (string, bool) {
+	if !options.sorted() {
+		return "id", true
+	}
+
+	columns, ok := sqlSortColumns[options.SortBy]
+	if !ok {
+		return "", false
+	}
+
+	order := make([]string, 0, len(columns)+1)
+	for _, column := range columns {
+		if options.Descending {
+			column += " DESC"
+		}
+		order = append(order, column)
+	}
+	return strings.Join(append(order, "id"), ", "), true
+}
+
+//SQLRevisionStore stores laptop revisions in a SQL database, each as a LaptopRevision in protobuf binary
+type SQLRevisionStore struct {
+	db *sql.DB
+}
+
+//NewSQLRevisionStore returns a new SQLRevisionStore using a database opened with OpenSQLiteDB.
+//The database can be shared with a SQLLaptopStore
+func NewSQLRevisionStore(db *sql.DB) *SQLRevisionStore {
+	return &SQLRevisionStore{
+		db: db,
+	}
+}
+
+//Append stores a copy of the revision and returns its number
+func (store *SQLRevisionStore) Append(revision *pb.LaptopRevision) (uint64, error) {
+	tx, err := store.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("Cannot begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	var last uint64
+	err = tx.QueryRow("SELECT COALESCE(MAX(revision), 0) FROM revisions WHERE laptop_id = ?", revision.GetLaptopId()).Scan(&last)
+	if err != nil {
+		return 0, fmt.Errorf("Cannot find last revision: %v", err)
+	}
+
+	other := proto.Clone(revision).(*pb.LaptopRevision)
+	other.Revision = last + 1
+
+	data, err := proto.Marshal(other)
+	if err != nil {
+		return 0, fmt.Errorf("Cannot marshal revision: %v", err)
+	}
+
+	_, err = tx.Exec("INSERT INTO revisions (laptop_id, revision, data) VALUES (?, ?, ?)", other.GetLaptopId(), other.Revision, data)
+	if err != nil {
+		return 0, fmt.Errorf("Cannot insert revision: %v", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+	return other.Revision, nil
+}
+
+//Find returns a revision of a laptop, or nil if there is no such revision
+func (store *SQLRevisionStore) Find(laptopID string, revision uint64) (*pb.LaptopRevision, error) {
+	var data []byte
+	err := store.db.QueryRow("SELECT data FROM revisions WHERE laptop_id = ? AND revision = ?", laptopID, revision).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Cannot find revision: %v", err)
+	}
+
+	found := &pb.LaptopRevision{}
+	err = proto.Unmarshal(data, found)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot unmarshal revision: %v", err)
+	}
+	return found, nil
+}
+
+//List returns up to limit revisions of a laptop in ascending order, starting right after afterRevision
+func (store *SQLRevisionStore) List(laptopID string, afterRevision uint64, limit int) ([]*pb.LaptopRevision, error) {
+	rows, err := store.db.Query(
+		"SELECT data FROM revisions WHERE laptop_id = ? AND revision > ? ORDER BY revision LIMIT ?",
+		laptopID, afterRevision, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot list revisions: %v", err)
+	}
+	defer rows.Close()
+
+	revisions := []*pb.LaptopRevision{}
+	for rows.Next() {
+		var data []byte
+		err = rows.Scan(&data)
+		if err != nil {
+			return nil, fmt.Errorf("Cannot read revision: %v", err)
+		}
+
+		revision := &pb.LaptopRevision{}
+		err = proto.Unmarshal(data, revision)
+		if err != nil {
+			return nil, fmt.Errorf("Cannot unmarshal revision: %v", err)
+		}
+		revisions = append(revisions, revision)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("Cannot list revisions: %v", err)
+	}
+	return revisions, nil
+}
+
+//SQLTrashStore stores soft-deleted laptops in a SQL database, each as a TrashedLaptop in protobuf binary
+type SQLTrashStore struct {
+	db *sql.DB
+}
+
+//NewSQLTrashStore returns a new SQLTrashStore using a database opened with OpenSQLiteDB.
+//The database can be shared with a SQLLaptopStore
+func NewSQLTrashStore(db *sql.DB) *SQLTrashStore {
+	return &SQLTrashStore{
+		db: db,
+	}
+}
+
+//Put moves a copy of the laptop to the trash, there can only be one laptop with the same ID
+func (store *SQLTrashStore) Put(laptop *pb.TrashedLaptop) error {
+	data, err := proto.Marshal(laptop)
+	if err != nil {
+		return fmt.Errorf("Cannot marshal trashed laptop: %v", err)
+	}
+
+	tx, err := store.db.Begin()
+	if err != nil {
+		return fmt.Errorf("Cannot begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	var count int
+	err = tx.QueryRow("SELECT COUNT(*) FROM trash WHERE id = ?", laptop.GetLaptop().GetId()).Scan(&count)
+	if err != nil {
+		return fmt.Errorf("Cannot find laptop in the trash: %v", err)
+	}
+	if count > 0 {
+		return ErrAlreadyExists
+	}
+
+	_, err = tx.Exec("INSERT INTO trash (id, data) VALUES (?, ?)", laptop.GetLaptop().GetId(), data)
+	if err != nil {
+		return fmt.Errorf("Cannot insert trashed laptop: %v", err)
+	}
+	return tx.Commit()
+}
+
+//Find returns a laptop of the trash by ID, or nil if it is not in the trash
+func (store *SQLTrashStore) Find(id string) (*pb.TrashedLaptop, error) {
+	var data []byte
+	err := store.db.QueryRow("SELECT data FROM trash WHERE id = ?", id).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Cannot find laptop in the trash: %v", err)
+	}
+
+	laptop := &pb.TrashedLaptop{}
+	err = proto.Unmarshal(data, laptop)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot unmarshal trashed laptop: %v", err)
+	}
+	return laptop, nil
+}
+
+//Remove takes a laptop out of the trash by ID
+func (store *SQLTrashStore) Remove(id string) error {
+	result, err := store.db.Exec("DELETE FROM trash WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("Cannot remove laptop from the trash: %v", err)
+	}
+
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("Cannot remove laptop from the trash: %v", err)
+	}
+	if removed == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+//List returns up to limit laptops of the trash in ascending ID order, starting right after afterID
+func (store *SQLTrashStore) List(afterID string, limit int) ([]*pb.TrashedLaptop, error) {
+	rows, err := store.db.Query("SELECT data FROM trash WHERE id > ? ORDER BY id LIMIT ?", afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot list trashed laptops: %v", err)
+	}
+	defer rows.Close()
+
+	laptops := []*pb.TrashedLaptop{}
+	for rows.Next() {
+		var data []byte
+		err = rows.Scan(&data)
+		if err != nil {
+			return nil, fmt.Errorf("Cannot read trashed laptop: %v", err)
+		}
+
+		laptop := &pb.TrashedLaptop{}
+		err = proto.Unmarshal(data, laptop)
+		if err != nil {
+			return nil, fmt.Errorf("Cannot unmarshal trashed laptop: %v", err)
+		}
+		laptops = append(laptops, laptop)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("Cannot list trashed laptops: %v", err)
+	}
+	return laptops, nil
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"demo-grpc/pb"
+	"demo-grpc/sample"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSQLLaptopStore(t *testing.T) (*SQLLaptopStore, func()) {
+	folder, err := ioutil.TempDir("", "sql")
+	require.NoError(t, err)
+
+	db, err := OpenSQLiteDB(filepath.Join(folder, "laptops.db"))
+	require.NoError(t, err)
+
+	store := NewSQLLaptopStore(db)
+	return store, func() {
+		store.Close()
+		os.RemoveAll(folder)
+	}
+}
+
+func TestSQLLaptopStore(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := newTestSQLLaptopStore(t)
+	defer cleanup()
+
+	laptop := sample.NewLaptop()
+	laptop.Weight = &pb.Laptop_WeightLb{WeightLb: 4}
+	require.NoError(t, store.Save(laptop))
+	require.Equal(t, ErrAlreadyExists, store.Save(laptop))
+
+	found, version, err := store.FindWithVersion(laptop.Id)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(laptop, found))
+	require.Equal(t, firstVersion, version)
+
+	laptop.Name = "Updated"
+	laptop.Gpus = laptop.Gpus[:0]
+	laptop.Screen.Resolution = nil
+	laptop.Keyboard = nil
+	version, err = store.Update(laptop, firstVersion)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), version)
+
+	_, err = store.Update(laptop, firstVersion)
+	require.Equal(t, ErrVersionMismatch, err)
+
+	found, err = store.Find(laptop.Id)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(laptop, found))
+
+	require.Equal(t, ErrVersionMismatch, store.Delete(laptop.Id, firstVersion))
+	require.NoError(t, store.Delete(laptop.Id, 2))
+	require.Equal(t, ErrNotFound, store.Delete(laptop.Id, 0))
+
+	found, err = store.Find(laptop.Id)
+	require.NoError(t, err)
+	require.Nil(t, found)
+
+	//migrating an up to date schema does nothing
+	require.NoError(t, MigrateSQL(store.db))
+}
+
+func TestSQLLaptopStoreSearch(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := newTestSQLLaptopStore(t)
+	defer cleanup()
+
+	laptops := []*pb.Laptop{}
+	for i := 0; i < 30; i++ {
+		laptop := sample.NewLaptop()
+		if i%3 == 0 {
+			laptop.Weight = &pb.Laptop_WeightLb{WeightLb: laptop.GetWeightKg() / kgPerLb}
+		}
+		require.NoError(t, store.Save(laptop))
+		laptops = append(laptops, laptop)
+	}
+
+	filters := []*pb.Filter{
+		nil,
+		{MaxPriceUsd: 2000, MinCpuCores: 4, MinCpuGhz: 2.5, MinRam: &pb.Memory{Value: 8, Unit: pb.Memory_GIGABYTE}},
+		{Brand: "dell", Name: "o"},
+		{GpuBrand: "nvidia", MinGpuMemory: &pb.Memory{Value: 3, Unit: pb.Memory_GIGABYTE}},
+		{MinSsd: &pb.Memory{Value: 512, Unit: pb.Memory_GIGABYTE}},
+		{MinHdd: &pb.Memory{Value: 1, Unit: pb.Memory_TERABYTE}},
+		{MinScreenSizeInch: 14, MaxScreenSizeInch: 16, MinResolution: &pb.Screen_Resolution{Width: 1920}},
+		{Panel: pb.Screen_IPS, Multitouch: &wrappers.BoolValue{Value: false}},
+		{MinWeightKg: 1.5, MaxWeightKg: 2.5},
+		{MinReleaseYear: 2016, MaxReleaseYear: 2019},
+		{KeyboardLayout: pb.Keyboard_QWERTY, Backlit: &wrappers.BoolValue{Value: true}},
+	}
+
+	for _, filter := range filters {
+		expected := []string{}
+		query := CompileFilter(filter)
+		for _, laptop := range laptops {
+			if query.Match(laptop) {
+				expected = append(expected, laptop.Id)
+			}
+		}
+		sort.Strings(expected)
+
+		found := []string{}
+		err := store.Search(context.Background(), filter, nil, func(laptop *pb.Laptop) error {
+			found = append(found, laptop.Id)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, expected, found, "filter %v", filter)
+	}
+
+	options := &SearchOptions{SortBy: pb.SearchLaptopRequest_PRICE, Descending: true, MaxResults: 5}
+	prices := []float64{}
+	err := store.Search(context.Background(), nil, options, func(laptop *pb.Laptop) error {
+		prices = append(prices, laptop.GetPriceUsd())
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, prices, 5)
+	require.True(t, sort.IsSorted(sort.Reverse(sort.Float64Slice(prices))))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = store.Search(ctx, nil, nil, func(laptop *pb.Laptop) error {
+		return nil
+	})
+	require.Error(t, err)
+}
+
+func TestSQLRevisionStore(t *testing.T) {
+	t.Parallel()
+
+	folder, err := ioutil.TempDir("", "sql")
+	require.NoError(t, err)
+	defer os.RemoveAll(folder)
+
+	path := filepath.Join(folder, "laptops.db")
+	db, err := OpenSQLiteDB(path)
+	require.NoError(t, err)
+
+	//the history of a laptop is kept in the same database as the laptop, and outlives it
+	laptopStore := NewSQLLaptopStore(db)
+	store := NewSQLRevisionStore(db)
+
+	laptop := sample.NewLaptop()
+	require.NoError(t, laptopStore.Save(laptop))
+	_, err = store.Append(&pb.LaptopRevision{LaptopId: laptop.Id, Laptop: laptop, Version: firstVersion})
+	require.NoError(t, err)
+	require.NoError(t, laptopStore.Delete(laptop.Id, 0))
+	_, err = store.Append(&pb.LaptopRevision{LaptopId: laptop.Id, Operation: pb.LaptopRevision_DELETE})
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	db, err = OpenSQLiteDB(path)
+	require.NoError(t, err)
+	defer db.Close()
+
+	store = NewSQLRevisionStore(db)
+
+	revisions, err := store.List(laptop.Id, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	require.True(t, proto.Equal(laptop, revisions[0].GetLaptop()))
+	require.Equal(t, pb.LaptopRevision_DELETE, revisions[1].GetOperation())
+
+	number, err := store.Append(&pb.LaptopRevision{LaptopId: laptop.Id})
+	require.NoError(t, err)
+	require.EqualValues(t, 3, number)
+}
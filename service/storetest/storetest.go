@@ -0,0 +1,1078 @@
+//Package storetest checks that a store implementation behaves like the stores of the service package expect.
+//A backend runs the suite of each store it implements from its own tests, with a factory for new empty stores
+package storetest
+
+import (
+	"bytes"
+	"context"
+	"demo-grpc/pb"
+	"demo-grpc/sample"
+	"demo-grpc/service"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+//concurrency is the number of goroutines that use a store at the same time in the concurrent tests
+const concurrency = 8
+
+//maxImageSize is the size limit of the images saved by the image store tests
+const maxImageSize = 1 << 20
+
+//LaptopStoreFactory returns a new empty laptop store, which it should close with t.Cleanup if needed
+type LaptopStoreFactory func(t *testing.T) service.LaptopStore
+
+//UserStoreFactory returns a new empty user store, which it should close with t.Cleanup if needed
+type UserStoreFactory func(t *testing.T) service.UserStore
+
+//RatingStoreFactory returns a new empty rating store, which it should close with t.Cleanup if needed
+type RatingStoreFactory func(t *testing.T) service.RatingStore
+
+//ImageStoreFactory returns a new empty image store that writes the images to imageFolder,
+//which it should close with t.Cleanup if needed
+type ImageStoreFactory func(t *testing.T, imageFolder string) service.ImageStore
+
+//RevisionStoreFactory returns a new empty revision store, which it should close with t.Cleanup if needed
+type RevisionStoreFactory func(t *testing.T) service.RevisionStore
+
+//TrashStoreFactory returns a new empty trash store, which it should close with t.Cleanup if needed
+type TrashStoreFactory func(t *testing.T) service.TrashStore
+
+//RunLaptopStoreTests runs the laptop store suite against the stores returned by newStore
+func RunLaptopStoreTests(t *testing.T, newStore LaptopStoreFactory) {
+	t.Run("save_and_find", func(t *testing.T) { testLaptopSaveAndFind(t, newStore(t)) })
+	t.Run("update", func(t *testing.T) { testLaptopUpdate(t, newStore(t)) })
+	t.Run("delete", func(t *testing.T) { testLaptopDelete(t, newStore(t)) })
+	t.Run("save_all", func(t *testing.T) { testLaptopSaveAll(t, newStore(t)) })
+	t.Run("list", func(t *testing.T) { testLaptopList(t, newStore(t)) })
+	t.Run("filter", func(t *testing.T) { testLaptopFilter(t, newStore(t)) })
+	t.Run("search_options", func(t *testing.T) { testLaptopSearchOptions(t, newStore(t)) })
+	t.Run("search_cancelled", func(t *testing.T) { testLaptopSearchCancelled(t, newStore(t)) })
+	t.Run("concurrent", func(t *testing.T) { testLaptopConcurrent(t, newStore(t)) })
+}
+
+func testLaptopSaveAndFind(t *testing.T, store service.LaptopStore) {
+	laptop := sample.NewLaptop()
+	require.NoError(t, store.Save(laptop))
+	require.Equal(t, service.ErrAlreadyExists, store.Save(laptop))
+
+	//the store keeps its own copy of the laptop
+	saved := proto.Clone(laptop).(*pb.Laptop)
+	laptop.Name = "Changed after save"
+
+	found, version, err := store.FindWithVersion(laptop.Id)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(saved, found))
+	require.Equal(t, uint64(1), version)
+
+	found, err = store.Find(laptop.Id)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(saved, found))
+
+	found, err = store.Find("missing")
+	require.NoError(t, err)
+	require.Nil(t, found)
+
+	found, version, err = store.FindWithVersion("missing")
+	require.NoError(t, err)
+	require.Nil(t, found)
+	require.Zero(t, version)
+}
+
+func testLaptopUpdate(t *testing.T, store service.LaptopStore) {
+	laptop := sample.NewLaptop()
+	require.NoError(t, store.Save(laptop))
+
+	laptop.PriceUsd = 999
+	version, err := store.Update(laptop, 1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), version)
+
+	_, err = store.Update(laptop, 1)
+	require.Equal(t, service.ErrVersionMismatch, err)
+
+	laptop.PriceUsd = 1999
+	version, err = store.Update(laptop, 0)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), version)
+
+	found, version, err := store.FindWithVersion(laptop.Id)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(laptop, found))
+	require.Equal(t, uint64(3), version)
+
+	_, err = store.Update(sample.NewLaptop(), 0)
+	require.Equal(t, service.ErrNotFound, err)
+}
+
+func testLaptopDelete(t *testing.T, store service.LaptopStore) {
+	laptop := sample.NewLaptop()
+	require.NoError(t, store.Save(laptop))
+	_, err := store.Update(laptop, 1)
+	require.NoError(t, err)
+
+	require.Equal(t, service.ErrVersionMismatch, store.Delete(laptop.Id, 1))
+	require.NoError(t, store.Delete(laptop.Id, 2))
+	require.Equal(t, service.ErrNotFound, store.Delete(laptop.Id, 0))
+
+	found, err := store.Find(laptop.Id)
+	require.NoError(t, err)
+	require.Nil(t, found)
+
+	//a deleted ID can be used again, past the last version of the deleted laptop,
+	//so that a version read before the delete never matches the new laptop
+	require.NoError(t, store.Save(laptop))
+	_, version, err := store.FindWithVersion(laptop.Id)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), version)
+
+	_, err = store.Update(laptop, 2)
+	require.Equal(t, service.ErrVersionMismatch, err)
+	require.Equal(t, service.ErrVersionMismatch, store.Delete(laptop.Id, 1))
+
+	//and so can it in a batch
+	saver, ok := store.(service.LaptopBatchSaver)
+	if !ok {
+		return
+	}
+	require.NoError(t, store.Delete(laptop.Id, 3))
+	require.NoError(t, saver.SaveAll([]*pb.Laptop{laptop}))
+	_, version, err = store.FindWithVersion(laptop.Id)
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), version)
+}
+
+//testLaptopSaveAll only runs against the stores that can save laptops in a single transaction
+func testLaptopSaveAll(t *testing.T, store service.LaptopStore) {
+	saver, ok := store.(service.LaptopBatchSaver)
+	if !ok {
+		t.Skip("store is not a LaptopBatchSaver")
+	}
+
+	existing := sample.NewLaptop()
+	require.NoError(t, store.Save(existing))
+
+	laptops := []*pb.Laptop{sample.NewLaptop(), sample.NewLaptop()}
+	err := saver.SaveAll(append(laptops, existing))
+	require.True(t, errors.Is(err, service.ErrAlreadyExists))
+
+	//a batch with the same laptop twice is not saved either
+	err = saver.SaveAll([]*pb.Laptop{laptops[0], laptops[1], laptops[0]})
+	require.True(t, errors.Is(err, service.ErrAlreadyExists))
+
+	for _, laptop := range laptops {
+		found, err := store.Find(laptop.Id)
+		require.NoError(t, err)
+		require.Nil(t, found)
+	}
+
+	require.NoError(t, saver.SaveAll(laptops))
+	for _, laptop := range laptops {
+		found, version, err := store.FindWithVersion(laptop.Id)
+		require.NoError(t, err)
+		require.True(t, proto.Equal(laptop, found))
+		require.Equal(t, uint64(1), version)
+	}
+}
+
+func testLaptopList(t *testing.T, store service.LaptopStore) {
+	ids := []string{}
+	for i := 0; i < 7; i++ {
+		laptop := sample.NewLaptop()
+		require.NoError(t, store.Save(laptop))
+		ids = append(ids, laptop.Id)
+	}
+	sort.Strings(ids)
+
+	listed := []string{}
+	afterID := ""
+	for {
+		laptops, err := store.List(context.Background(), afterID, 3)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(laptops), 3)
+		if len(laptops) == 0 {
+			break
+		}
+
+		for _, laptop := range laptops {
+			listed = append(listed, laptop.Id)
+		}
+		afterID = laptops[len(laptops)-1].Id
+	}
+
+	require.Equal(t, ids, listed)
+}
+
+//filterLaptops returns laptops whose fields are known, so that the filters of testLaptopFilter have a known result
+func filterLaptops() []*pb.Laptop {
+	laptops := []*pb.Laptop{sample.NewLaptop(), sample.NewLaptop(), sample.NewLaptop()}
+
+	laptops[0].Brand = "Lenovo"
+	laptops[0].Name = "Thinkpad X1"
+	laptops[0].PriceUsd = 2000
+	laptops[0].ReleaseYear = 2018
+	laptops[0].Weight = &pb.Laptop_WeightLb{WeightLb: 4}
+	laptops[0].Cpu.NumberCores = 8
+	laptops[0].Ram = &pb.Memory{Value: 16, Unit: pb.Memory_GIGABYTE}
+	laptops[0].Gpus = []*pb.GPU{{Brand: "Nvidia", Memory: &pb.Memory{Value: 6, Unit: pb.Memory_GIGABYTE}}}
+	laptops[0].Storages = []*pb.Storage{
+		{Driver: pb.Storage_SSD, Memory: &pb.Memory{Value: 512, Unit: pb.Memory_GIGABYTE}},
+		{Driver: pb.Storage_SSD, Memory: &pb.Memory{Value: 512, Unit: pb.Memory_GIGABYTE}},
+	}
+	laptops[0].Screen = &pb.Screen{
+		SizeInch:   14,
+		Resolution: &pb.Screen_Resolution{Width: 2560, Height: 1440},
+		Panel:      pb.Screen_IPS,
+	}
+	laptops[0].Keyboard = &pb.Keyboard{Layout: pb.Keyboard_QWERTY, Backlit: true}
+
+	laptops[1].Brand = "Dell"
+	laptops[1].Name = "XPS"
+	laptops[1].PriceUsd = 1500
+	laptops[1].ReleaseYear = 2020
+	laptops[1].Weight = &pb.Laptop_WeightKg{WeightKg: 1.2}
+	laptops[1].Cpu.NumberCores = 4
+	laptops[1].Ram = &pb.Memory{Value: 8192, Unit: pb.Memory_MEGABYTE}
+	laptops[1].Gpus = []*pb.GPU{{Brand: "AMD", Memory: &pb.Memory{Value: 2, Unit: pb.Memory_GIGABYTE}}}
+	laptops[1].Storages = []*pb.Storage{
+		{Driver: pb.Storage_SSD, Memory: &pb.Memory{Value: 256, Unit: pb.Memory_GIGABYTE}},
+		{Driver: pb.Storage_HDD, Memory: &pb.Memory{Value: 1, Unit: pb.Memory_TERABYTE}},
+	}
+	laptops[1].Screen = &pb.Screen{
+		SizeInch:   13.3,
+		Resolution: &pb.Screen_Resolution{Width: 3840, Height: 2160},
+		Panel:      pb.Screen_OLED,
+		Multitouch: true,
+	}
+	laptops[1].Keyboard = &pb.Keyboard{Layout: pb.Keyboard_AZERTY, Backlit: false}
+
+	laptops[2].Brand = "Apple"
+	laptops[2].Name = "Macbook Pro"
+	laptops[2].PriceUsd = 3000
+	laptops[2].ReleaseYear = 2019
+	laptops[2].Weight = &pb.Laptop_WeightKg{WeightKg: 2}
+	laptops[2].Cpu.NumberCores = 6
+	laptops[2].Ram = &pb.Memory{Value: 32, Unit: pb.Memory_GIGABYTE}
+	laptops[2].Gpus = []*pb.GPU{
+		{Brand: "Intel", Memory: &pb.Memory{Value: 1, Unit: pb.Memory_GIGABYTE}},
+		{Brand: "AMD", Memory: &pb.Memory{Value: 8, Unit: pb.Memory_GIGABYTE}},
+	}
+	laptops[2].Storages = []*pb.Storage{
+		{Driver: pb.Storage_SSD, Memory: &pb.Memory{Value: 2, Unit: pb.Memory_TERABYTE}},
+	}
+	laptops[2].Screen = &pb.Screen{
+		SizeInch:   16,
+		Resolution: &pb.Screen_Resolution{Width: 3072, Height: 1920},
+		Panel:      pb.Screen_IPS,
+	}
+	laptops[2].Keyboard = &pb.Keyboard{Layout: pb.Keyboard_QWERTY, Backlit: true}
+
+	return laptops
+}
+
+func testLaptopFilter(t *testing.T, store service.LaptopStore) {
+	laptops := filterLaptops()
+	for _, laptop := range laptops {
+		require.NoError(t, store.Save(laptop))
+	}
+
+	testCases := []struct {
+		name    string
+		filter  *pb.Filter
+		matches []int
+	}{
+		{"nil_filter", nil, []int{0, 1, 2}},
+		{"empty_filter", &pb.Filter{}, []int{0, 1, 2}},
+		{"price", &pb.Filter{MinPriceUsd: 1500, MaxPriceUsd: 2000}, []int{0, 1}},
+		{"cpu_cores", &pb.Filter{MinCpuCores: 6}, []int{0, 2}},
+		{"ram_units", &pb.Filter{MinRam: &pb.Memory{Value: 8, Unit: pb.Memory_GIGABYTE}}, []int{0, 1, 2}},
+		{"ram", &pb.Filter{MinRam: &pb.Memory{Value: 16, Unit: pb.Memory_GIGABYTE}}, []int{0, 2}},
+		{"brand_case_insensitive", &pb.Filter{Brand: "dell"}, []int{1}},
+		{"name_substring", &pb.Filter{Name: "BOOK"}, []int{2}},
+		{"gpu_brand", &pb.Filter{GpuBrand: "amd"}, []int{1, 2}},
+		{"same_gpu", &pb.Filter{GpuBrand: "Intel", MinGpuMemory: &pb.Memory{Value: 4, Unit: pb.Memory_GIGABYTE}}, []int{}},
+		{"gpu_memory", &pb.Filter{MinGpuMemory: &pb.Memory{Value: 6, Unit: pb.Memory_GIGABYTE}}, []int{0, 2}},
+		{"total_ssd", &pb.Filter{MinSsd: &pb.Memory{Value: 1, Unit: pb.Memory_TERABYTE}}, []int{0, 2}},
+		{"hdd", &pb.Filter{MinHdd: &pb.Memory{Value: 500, Unit: pb.Memory_GIGABYTE}}, []int{1}},
+		{"screen_size", &pb.Filter{MinScreenSizeInch: 13.3, MaxScreenSizeInch: 14}, []int{0, 1}},
+		{"resolution", &pb.Filter{MinResolution: &pb.Screen_Resolution{Width: 3000, Height: 1900}}, []int{1, 2}},
+		{"panel", &pb.Filter{Panel: pb.Screen_IPS}, []int{0, 2}},
+		{"multitouch", &pb.Filter{Multitouch: &wrappers.BoolValue{Value: false}}, []int{0, 2}},
+		{"weight_lb_normalised", &pb.Filter{MinWeightKg: 1.8, MaxWeightKg: 1.9}, []int{0}},
+		{"release_year", &pb.Filter{MinReleaseYear: 2019, MaxReleaseYear: 2020}, []int{1, 2}},
+		{"keyboard_layout", &pb.Filter{KeyboardLayout: pb.Keyboard_QWERTY}, []int{0, 2}},
+		{"not_backlit", &pb.Filter{Backlit: &wrappers.BoolValue{Value: false}}, []int{1}},
+		{"combined", &pb.Filter{MaxPriceUsd: 2500, Panel: pb.Screen_IPS, GpuBrand: "nvidia"}, []int{0}},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			expected := []string{}
+			for _, i := range tc.matches {
+				expected = append(expected, laptops[i].Id)
+			}
+			sort.Strings(expected)
+
+			found := searchIDs(t, store, tc.filter, nil)
+			sort.Strings(found)
+			require.Equal(t, expected, found)
+		})
+	}
+}
+
+func testLaptopSearchOptions(t *testing.T, store service.LaptopStore) {
+	laptops := filterLaptops()
+	for _, laptop := range laptops {
+		require.NoError(t, store.Save(laptop))
+	}
+
+	options := &service.SearchOptions{SortBy: pb.SearchLaptopRequest_PRICE}
+	require.Equal(t, []string{laptops[1].Id, laptops[0].Id, laptops[2].Id}, searchIDs(t, store, nil, options))
+
+	options = &service.SearchOptions{SortBy: pb.SearchLaptopRequest_RAM, Descending: true, MaxResults: 2}
+	require.Equal(t, []string{laptops[2].Id, laptops[0].Id}, searchIDs(t, store, nil, options))
+
+	options = &service.SearchOptions{MaxResults: 2}
+	require.Len(t, searchIDs(t, store, nil, options), 2)
+
+	query, err := service.ParseQuery("cpu.cores >= 6 and not brand = apple")
+	require.NoError(t, err)
+	options = &service.SearchOptions{Query: query}
+	require.Equal(t, []string{laptops[0].Id}, searchIDs(t, store, nil, options))
+
+	options = &service.SearchOptions{Text: "thinkpad"}
+	require.Equal(t, []string{laptops[0].Id}, searchIDs(t, store, &pb.Filter{MinPriceUsd: 1000}, options))
+}
+
+func testLaptopSearchCancelled(t *testing.T, store service.LaptopStore) {
+	for i := 0; i < 3; i++ {
+		require.NoError(t, store.Save(sample.NewLaptop()))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := store.Search(ctx, nil, nil, func(laptop *pb.Laptop) error {
+		return nil
+	})
+	require.Error(t, err)
+
+	//an error returned by found stops the search
+	stop := fmt.Errorf("stop")
+	calls := 0
+	err = store.Search(context.Background(), nil, nil, func(laptop *pb.Laptop) error {
+		calls++
+		return stop
+	})
+	require.Equal(t, stop, err)
+	require.Equal(t, 1, calls)
+}
+
+func testLaptopConcurrent(t *testing.T, store service.LaptopStore) {
+	const perGoroutine = 10
+	runConcurrently(t, func(i int) error {
+		return concurrentLaptopWrites(store, perGoroutine)
+	})
+	require.Len(t, searchIDs(t, store, nil, nil), concurrency*perGoroutine)
+}
+
+func concurrentLaptopWrites(store service.LaptopStore, count int) error {
+	for i := 0; i < count; i++ {
+		laptop := sample.NewLaptop()
+		err := store.Save(laptop)
+		if err != nil {
+			return err
+		}
+
+		laptop.PriceUsd++
+		_, err = store.Update(laptop, 1)
+		if err != nil {
+			return err
+		}
+
+		_, err = store.Find(laptop.Id)
+		if err != nil {
+			return err
+		}
+
+		err = store.Search(context.Background(), &pb.Filter{MinCpuCores: 1}, nil, func(laptop *pb.Laptop) error {
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func searchIDs(t *testing.T, store service.LaptopStore, filter *pb.Filter, options *service.SearchOptions) []string {
+	ids := []string{}
+	err := store.Search(context.Background(), filter, options, func(laptop *pb.Laptop) error {
+		ids = append(ids, laptop.Id)
+		return nil
+	})
+	require.NoError(t, err)
+	return ids
+}
+
+//RunUserStoreTests runs the user store suite against the stores returned by newStore
+func RunUserStoreTests(t *testing.T, newStore UserStoreFactory) {
+	t.Run("save_and_find", func(t *testing.T) {
+		store := newStore(t)
+
+		user, err := service.NewUser("user1", "secret", "user")
+		require.NoError(t, err)
+		require.NoError(t, store.Save(user))
+		require.Equal(t, service.ErrAlreadyExists, store.Save(user))
+
+		found, err := store.Find("user1")
+		require.NoError(t, err)
+		require.Equal(t, user, found)
+		require.True(t, found.IsCorrectPassword("secret"))
+
+		found, err = store.Find("missing")
+		require.NoError(t, err)
+		require.Nil(t, found)
+	})
+
+	t.Run("list_and_delete", func(t *testing.T) {
+		store := newStore(t)
+
+		users, err := store.List()
+		require.NoError(t, err)
+		require.Empty(t, users)
+
+		for _, username := range []string{"user2", "user1", "user3"} {
+			user, err := service.NewUser(username, "secret", "user")
+			require.NoError(t, err)
+			require.NoError(t, store.Save(user))
+		}
+
+		require.NoError(t, store.Delete("user2"))
+		require.Equal(t, service.ErrNotFound, store.Delete("user2"))
+
+		users, err = store.List()
+		require.NoError(t, err)
+		require.Len(t, users, 2)
+		require.Equal(t, "user1", users[0].Username)
+		require.Equal(t, "user3", users[1].Username)
+
+		found, err := store.Find("user2")
+		require.NoError(t, err)
+		require.Nil(t, found)
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		store := newStore(t)
+		runConcurrently(t, func(i int) error {
+			user, err := service.NewUser(fmt.Sprintf("user%d", i), "secret", "user")
+			if err != nil {
+				return err
+			}
+
+			err = store.Save(user)
+			if err != nil {
+				return err
+			}
+
+			_, err = store.Find(user.Username)
+			return err
+		})
+
+		for i := 0; i < concurrency; i++ {
+			found, err := store.Find(fmt.Sprintf("user%d", i))
+			require.NoError(t, err)
+			require.NotNil(t, found)
+		}
+	})
+}
+
+//RunRatingStoreTests runs the rating store suite against the stores returned by newStore
+func RunRatingStoreTests(t *testing.T, newStore RatingStoreFactory) {
+	t.Run("add_and_find", func(t *testing.T) {
+		store := newStore(t)
+
+		rating, err := store.Find("laptop")
+		require.NoError(t, err)
+		require.Nil(t, rating)
+
+		rating, err = store.Add("laptop", 4)
+		require.NoError(t, err)
+		require.Equal(t, &service.Rating{Count: 1, Sum: 4}, rating)
+
+		rating, err = store.Add("laptop", 5)
+		require.NoError(t, err)
+		require.Equal(t, &service.Rating{Count: 2, Sum: 9}, rating)
+
+		rating, err = store.Find("laptop")
+		require.NoError(t, err)
+		require.Equal(t, &service.Rating{Count: 2, Sum: 9}, rating)
+
+		rating, err = store.Find("other")
+		require.NoError(t, err)
+		require.Nil(t, rating)
+	})
+
+	t.Run("set", func(t *testing.T) {
+		store := newStore(t)
+
+		require.NoError(t, store.Set("laptop", &service.Rating{Count: 3, Sum: 12}))
+		rating, err := store.Add("laptop", 4)
+		require.NoError(t, err)
+		require.Equal(t, &service.Rating{Count: 4, Sum: 16}, rating)
+
+		require.NoError(t, store.Set("laptop", &service.Rating{Count: 1, Sum: 2}))
+		rating, err = store.Find("laptop")
+		require.NoError(t, err)
+		require.Equal(t, &service.Rating{Count: 1, Sum: 2}, rating)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		store := newStore(t)
+
+		_, err := store.Add("laptop", 4)
+		require.NoError(t, err)
+		require.NoError(t, store.Delete("laptop"))
+		require.NoError(t, store.Delete("missing"))
+
+		rating, err := store.Find("laptop")
+		require.NoError(t, err)
+		require.Nil(t, rating)
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		store := newStore(t)
+		runConcurrently(t, func(i int) error {
+			_, err := store.Add("laptop", 1)
+			if err != nil {
+				return err
+			}
+
+			_, err = store.Find("laptop")
+			return err
+		})
+
+		rating, err := store.Find("laptop")
+		require.NoError(t, err)
+		require.Equal(t, &service.Rating{Count: concurrency, Sum: concurrency}, rating)
+	})
+}
+
+//RunImageStoreTests runs the image store suite against the stores returned by newStore
+func RunImageStoreTests(t *testing.T, newStore ImageStoreFactory) {
+	t.Run("save", func(t *testing.T) {
+		folder := tempFolder(t)
+		store := newStore(t, folder)
+
+		first := sample.NewImage(".jpg", 8, 8)
+		second := sample.NewImage(".png", 8, 8)
+		id1, err := service.SaveImage(store, "laptop", ".jpg", bytes.NewBuffer(first), maxImageSize)
+		require.NoError(t, err)
+		id2, err := service.SaveImage(store, "laptop", ".png", bytes.NewBuffer(second), maxImageSize)
+		require.NoError(t, err)
+		require.NotEmpty(t, id1)
+		require.NotEqual(t, id1, id2)
+
+		require.Equal(t, first, readImage(t, folder, id1))
+		require.Equal(t, second, readImage(t, folder, id2))
+
+		images, err := store.List("laptop")
+		require.NoError(t, err)
+		require.Len(t, images, 2)
+		require.Less(t, images[0].ID, images[1].ID)
+		for _, image := range images {
+			require.Equal(t, "laptop", image.LaptopID)
+			require.Contains(t, []string{id1, id2}, image.ID)
+		}
+
+		images, err = store.List("other")
+		require.NoError(t, err)
+		require.Empty(t, images)
+	})
+
+	t.Run("find_and_open", func(t *testing.T) {
+		folder := tempFolder(t)
+		store := newStore(t, folder)
+
+		image := sample.NewImage(".gif", 16, 9)
+		id, err := service.SaveImage(store, "laptop", ".gif", bytes.NewBuffer(image), maxImageSize)
+		require.NoError(t, err)
+
+		info, err := store.Find(id)
+		require.NoError(t, err)
+		require.Equal(t, id, info.ID)
+		require.Equal(t, "laptop", info.LaptopID)
+		require.Equal(t, ".gif", info.Type)
+		require.Equal(t, int64(len(image)), info.Size)
+		require.Equal(t, 16, info.Width)
+		require.Equal(t, 9, info.Height)
+
+		file, err := store.Open(id)
+		require.NoError(t, err)
+		data, err := ioutil.ReadAll(file)
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+		require.Equal(t, image, data)
+
+		info, err = store.Find("missing")
+		require.NoError(t, err)
+		require.Nil(t, info)
+
+		_, err = store.Open("missing")
+		require.Equal(t, service.ErrNotFound, err)
+
+		//an image whose file is gone cannot be opened
+		gone := uuid.New().String()
+		require.NoError(t, store.SaveInfo(&service.ImageInfo{ID: gone, LaptopID: "laptop", Type: ".jpg"}))
+		_, err = store.Open(gone)
+		require.Equal(t, service.ErrNotFound, err)
+	})
+
+	t.Run("save_info", func(t *testing.T) {
+		folder := tempFolder(t)
+		store := newStore(t, folder)
+
+		id := uuid.New().String()
+		info := &service.ImageInfo{ID: id, LaptopID: "laptop", Type: ".png", Path: filepath.Join(folder, id+".png"), Width: 8}
+		require.NoError(t, store.SaveInfo(info))
+
+		//the store keeps its own copy of the info
+		info.Width = 16
+		images, err := store.List("laptop")
+		require.NoError(t, err)
+		require.Equal(t, []*service.ImageInfo{{ID: id, LaptopID: "laptop", Type: ".png", Path: filepath.Join(folder, id+".png"), Width: 8}}, images)
+
+		//the paths are those of the files the store writes, whatever the given ones
+		other := &service.ImageInfo{
+			ID:       id,
+			LaptopID: "laptop",
+			Type:     ".JPEG",
+			Path:     "../../etc/passwd",
+			Variants: []service.ImageVariant{{MaxSide: 128, Type: ".png", Path: "/etc/shadow"}},
+		}
+		require.NoError(t, store.SaveInfo(other))
+		images, err = store.List("laptop")
+		require.NoError(t, err)
+		require.Equal(t, []*service.ImageInfo{{
+			ID:       id,
+			LaptopID: "laptop",
+			Type:     ".jpg",
+			Path:     filepath.Join(folder, id+".jpg"),
+			Variants: []service.ImageVariant{{MaxSide: 128, Type: ".png", Path: filepath.Join(folder, id+"_128.png")}},
+		}}, images)
+
+		for _, invalid := range []*service.ImageInfo{
+			{ID: "../../etc/passwd", LaptopID: "laptop", Type: ".jpg"},
+			{ID: id, LaptopID: "laptop", Type: "/../x"},
+			{ID: id, LaptopID: "laptop", Type: ".jpg", Variants: []service.ImageVariant{{MaxSide: 128, Type: "/../x"}}},
+		} {
+			require.Error(t, store.SaveInfo(invalid), invalid.ID+invalid.Type)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		folder := tempFolder(t)
+		store := newStore(t, folder)
+
+		id, err := service.SaveImage(store, "laptop", ".jpg", bytes.NewBuffer(sample.NewImage(".jpg", 8, 8)), maxImageSize)
+		require.NoError(t, err)
+		gone := uuid.New().String()
+		require.NoError(t, store.SaveInfo(&service.ImageInfo{ID: gone, LaptopID: "laptop", Type: ".jpg"}))
+
+		require.NoError(t, store.Delete(id))
+		require.Equal(t, service.ErrNotFound, store.Delete(id))
+
+		//an image whose file is already gone can still be deleted
+		require.NoError(t, store.Delete(gone))
+
+		images, err := store.List("laptop")
+		require.NoError(t, err)
+		require.Empty(t, images)
+
+		files, err := ioutil.ReadDir(folder)
+		require.NoError(t, err)
+		require.Empty(t, files)
+	})
+
+	t.Run("variants", func(t *testing.T) {
+		folder := tempFolder(t)
+		store := newStore(t, folder)
+
+		id, err := service.SaveImage(store, "laptop", ".gif", bytes.NewBuffer(sample.NewImage(".gif", 40, 20)), maxImageSize)
+		require.NoError(t, err)
+
+		large := sample.NewImage(".png", 32, 16)
+		small := sample.NewImage(".png", 8, 4)
+		require.NoError(t, saveVariant(store, id, 512, ".png", bytes.NewBuffer(large)))
+		require.NoError(t, saveVariant(store, id, 128, ".png", bytes.NewBuffer(large)))
+		//a variant replaces the one with the same size
+		require.NoError(t, saveVariant(store, id, 128, ".png", bytes.NewBuffer(small)))
+
+		info, err := store.Find(id)
+		require.NoError(t, err)
+		require.Len(t, info.Variants, 2)
+		require.Equal(t, service.ImageVariant{
+			MaxSide: 128,
+			Type:    ".png",
+			Path:    filepath.Join(folder, id+"_128.png"),
+			Size:    int64(len(small)),
+			Width:   8,
+			Height:  4,
+		}, info.Variants[0])
+		require.Equal(t, 512, info.Variants[1].MaxSide)
+		require.Equal(t, info.Variants[1], *info.Variant(512))
+		require.Nil(t, info.Variant(1024))
+
+		file, err := store.OpenVariant(id, 128)
+		require.NoError(t, err)
+		data, err := ioutil.ReadAll(file)
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+		require.Equal(t, small, data)
+
+		_, err = store.OpenVariant(id, 1024)
+		require.Equal(t, service.ErrNotFound, err)
+		_, err = store.OpenVariant("missing", 128)
+		require.Equal(t, service.ErrNotFound, err)
+
+		//the variant of an image that is gone is not kept
+		err = saveVariant(store, "missing", 128, ".png", bytes.NewBuffer(small))
+		require.Equal(t, service.ErrNotFound, err)
+		err = saveVariant(store, id, 1024, ".jpg", bytes.NewBuffer(small))
+		require.True(t, errors.Is(err, service.ErrImageFormat))
+
+		//a variant replaced by one of another type leaves no file behind
+		require.NoError(t, saveVariant(store, id, 512, ".gif", bytes.NewBuffer(sample.NewImage(".gif", 32, 16))))
+		info, err = store.Find(id)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(folder, id+"_512.gif"), info.Variant(512).Path)
+
+		files, err := ioutil.ReadDir(folder)
+		require.NoError(t, err)
+		require.Len(t, files, 3)
+
+		//the files of the variants go with the image
+		require.NoError(t, store.Delete(id))
+		files, err = ioutil.ReadDir(folder)
+		require.NoError(t, err)
+		require.Empty(t, files)
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		folder := tempFolder(t)
+		store := newStore(t, folder)
+
+		jpg := sample.NewImage(".jpg", 32, 24)
+		png := sample.NewImage(".png", 8, 8)
+		tests := []struct {
+			name      string
+			imageType string
+			data      []byte
+			err       error
+		}{
+			{"unsupported_type", ".exe", jpg, service.ErrUnsupportedImageType},
+			{"path_in_type", "/../../x.jpg", jpg, service.ErrUnsupportedImageType},
+			{"mismatch", ".jpg", png, service.ErrImageFormat},
+			{"not_an_image", ".png", []byte("not an image at all"), service.ErrImageFormat},
+			{"broken_header", ".png", png[:20], service.ErrImageFormat},
+		}
+		for _, tc := range tests {
+			_, err := service.SaveImage(store, "laptop", tc.imageType, bytes.NewBuffer(tc.data), maxImageSize)
+			require.True(t, errors.Is(err, tc.err), "%s: %v", tc.name, err)
+		}
+
+		files, err := ioutil.ReadDir(folder)
+		require.NoError(t, err)
+		require.Empty(t, files)
+
+		//the declared type is only checked, the file is named after the type of the data
+		id, err := service.SaveImage(store, "laptop", ".JPEG", bytes.NewBuffer(jpg), maxImageSize)
+		require.NoError(t, err)
+		info, err := store.Find(id)
+		require.NoError(t, err)
+		require.Equal(t, ".jpg", info.Type)
+		require.Equal(t, filepath.Join(folder, id+".jpg"), info.Path)
+		require.Equal(t, 32, info.Width)
+		require.Equal(t, 24, info.Height)
+	})
+
+	t.Run("too_large", func(t *testing.T) {
+		folder := tempFolder(t)
+		store := newStore(t, folder)
+
+		image := sample.NewImage(".png", 8, 8)
+		_, err := service.SaveImage(store, "laptop", ".png", bytes.NewBuffer(image), int64(len(image))-1)
+		require.Equal(t, service.ErrImageTooLarge, err)
+
+		id, err := service.SaveImage(store, "laptop", ".png", bytes.NewBuffer(image), int64(len(image)))
+		require.NoError(t, err)
+
+		//nothing is left of the image that was too large
+		images, err := store.List("laptop")
+		require.NoError(t, err)
+		require.Len(t, images, 1)
+		require.Equal(t, id, images[0].ID)
+
+		files, err := ioutil.ReadDir(folder)
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+	})
+
+	t.Run("failed_read", func(t *testing.T) {
+		folder := tempFolder(t)
+		store := newStore(t, folder)
+
+		failure := errors.New("connection lost")
+		data := io.MultiReader(bytes.NewBufferString("partial"), &failingReader{err: failure})
+		_, err := service.SaveImage(store, "laptop", ".jpg", data, maxImageSize)
+		require.True(t, errors.Is(err, failure))
+
+		files, err := ioutil.ReadDir(folder)
+		require.NoError(t, err)
+		require.Empty(t, files)
+	})
+
+	t.Run("missing_folder", func(t *testing.T) {
+		store := newStore(t, filepath.Join(tempFolder(t), "missing"))
+
+		_, err := service.SaveImage(store, "laptop", ".jpg", bytes.NewBuffer(sample.NewImage(".jpg", 8, 8)), maxImageSize)
+		require.Error(t, err)
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		folder := tempFolder(t)
+		store := newStore(t, folder)
+
+		runConcurrently(t, func(i int) error {
+			_, err := service.SaveImage(store, "laptop", ".jpg", bytes.NewBuffer(sample.NewImage(".jpg", 8, 8)), maxImageSize)
+			return err
+		})
+
+		files, err := ioutil.ReadDir(folder)
+		require.NoError(t, err)
+		require.Len(t, files, concurrency)
+	})
+}
+
+//saveVariant stages a variant read from data and saves it
+func saveVariant(store service.ImageStore, imageID string, maxSide int, imageType string, data io.Reader) error {
+	staged, err := store.Stage(imageType, data, maxImageSize)
+	if err != nil {
+		return err
+	}
+	defer staged.Discard()
+
+	return store.SaveVariant(imageID, maxSide, staged)
+}
+
+//RunRevisionStoreTests runs the revision store suite against the stores returned by newStore
+func RunRevisionStoreTests(t *testing.T, newStore RevisionStoreFactory) {
+	t.Run("append_and_find", func(t *testing.T) {
+		store := newStore(t)
+		laptop := sample.NewLaptop()
+
+		revision := &pb.LaptopRevision{
+			LaptopId:  laptop.Id,
+			Operation: pb.LaptopRevision_CREATE,
+			Username:  "admin1",
+			Laptop:    laptop,
+			Version:   1,
+		}
+		number, err := store.Append(revision)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, number)
+
+		//the store keeps its own copy of the revision
+		revision.Username = "changed"
+		number, err = store.Append(&pb.LaptopRevision{LaptopId: laptop.Id, Operation: pb.LaptopRevision_DELETE})
+		require.NoError(t, err)
+		require.EqualValues(t, 2, number)
+
+		found, err := store.Find(laptop.Id, 1)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, found.GetRevision())
+		require.Equal(t, "admin1", found.GetUsername())
+		require.True(t, proto.Equal(laptop, found.GetLaptop()))
+
+		for _, number := range []uint64{0, 3} {
+			found, err = store.Find(laptop.Id, number)
+			require.NoError(t, err)
+			require.Nil(t, found)
+		}
+
+		found, err = store.Find("other", 1)
+		require.NoError(t, err)
+		require.Nil(t, found)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		store := newStore(t)
+
+		for i := 0; i < 5; i++ {
+			_, err := store.Append(&pb.LaptopRevision{LaptopId: "laptop", Version: uint64(i + 1)})
+			require.NoError(t, err)
+		}
+		_, err := store.Append(&pb.LaptopRevision{LaptopId: "other"})
+		require.NoError(t, err)
+
+		revisions, err := store.List("laptop", 0, 3)
+		require.NoError(t, err)
+		require.Len(t, revisions, 3)
+
+		revisions, err = store.List("laptop", 3, 10)
+		require.NoError(t, err)
+		require.Len(t, revisions, 2)
+		require.EqualValues(t, 4, revisions[0].GetRevision())
+		require.EqualValues(t, 5, revisions[1].GetVersion())
+
+		revisions, err = store.List("missing", 0, 10)
+		require.NoError(t, err)
+		require.Empty(t, revisions)
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		store := newStore(t)
+		runConcurrently(t, func(i int) error {
+			_, err := store.Append(&pb.LaptopRevision{LaptopId: "laptop"})
+			return err
+		})
+
+		revisions, err := store.List("laptop", 0, 2*concurrency)
+		require.NoError(t, err)
+		require.Len(t, revisions, concurrency)
+		for i, revision := range revisions {
+			require.EqualValues(t, i+1, revision.GetRevision())
+		}
+	})
+}
+
+//RunTrashStoreTests runs the trash store suite against the stores returned by newStore
+func RunTrashStoreTests(t *testing.T, newStore TrashStoreFactory) {
+	t.Run("put_find_remove", func(t *testing.T) {
+		store := newStore(t)
+		laptop := sample.NewLaptop()
+
+		trashed := &pb.TrashedLaptop{Laptop: laptop, Version: 3, DeletedBy: "admin1"}
+		require.NoError(t, store.Put(trashed))
+		require.Equal(t, service.ErrAlreadyExists, store.Put(trashed))
+
+		//the store keeps its own copy of the laptop
+		trashed.DeletedBy = "changed"
+		found, err := store.Find(laptop.Id)
+		require.NoError(t, err)
+		require.Equal(t, "admin1", found.GetDeletedBy())
+		require.EqualValues(t, 3, found.GetVersion())
+		require.True(t, proto.Equal(laptop, found.GetLaptop()))
+
+		require.NoError(t, store.Remove(laptop.Id))
+		require.Equal(t, service.ErrNotFound, store.Remove(laptop.Id))
+
+		found, err = store.Find(laptop.Id)
+		require.NoError(t, err)
+		require.Nil(t, found)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		store := newStore(t)
+
+		ids := []string{}
+		for i := 0; i < 5; i++ {
+			laptop := sample.NewLaptop()
+			require.NoError(t, store.Put(&pb.TrashedLaptop{Laptop: laptop}))
+			ids = append(ids, laptop.Id)
+		}
+		sort.Strings(ids)
+
+		laptops, err := store.List("", 3)
+		require.NoError(t, err)
+		require.Len(t, laptops, 3)
+		require.Equal(t, ids[0], laptops[0].GetLaptop().GetId())
+
+		laptops, err = store.List(laptops[2].GetLaptop().GetId(), 10)
+		require.NoError(t, err)
+		require.Len(t, laptops, 2)
+		require.Equal(t, ids[3], laptops[0].GetLaptop().GetId())
+		require.Equal(t, ids[4], laptops[1].GetLaptop().GetId())
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		store := newStore(t)
+		runConcurrently(t, func(i int) error {
+			laptop := sample.NewLaptop()
+			err := store.Put(&pb.TrashedLaptop{Laptop: laptop})
+			if err != nil {
+				return err
+			}
+
+			_, err = store.Find(laptop.Id)
+			if err != nil {
+				return err
+			}
+			return store.Remove(laptop.Id)
+		})
+
+		laptops, err := store.List("", concurrency)
+		require.NoError(t, err)
+		require.Empty(t, laptops)
+	})
+}
+
+//runConcurrently calls fn with the numbers from 0 to concurrency in as many goroutines, and fails if any call fails
+func runConcurrently(t *testing.T, fn func(i int) error) {
+	wg := sync.WaitGroup{}
+	errs := make(chan error, concurrency)
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- fn(i)
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+}
+
+func tempFolder(t *testing.T) string {
+	folder, err := ioutil.TempDir("", "storetest")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(folder) })
+	return folder
+}
+
+//readImage returns the content of the only file of the folder whose name starts with the image ID
+func readImage(t *testing.T, folder string, imageID string) []byte {
+	paths, err := filepath.Glob(filepath.Join(folder, imageID+"*"))
+	require.NoError(t, err)
+	require.Len(t, paths, 1)
+
+	data, err := ioutil.ReadFile(paths[0])
+	require.NoError(t, err)
+	return data
+}
+
+//failingReader is a reader whose every read fails with err
+type failingReader struct {
+	err error
+}
+
+func (reader *failingReader) Read(p []byte) (int, error) {
+	return 0, reader.err
+}
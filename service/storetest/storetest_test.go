@@ -0,0 +1,111 @@
+package storetest
+
+import (
+	"demo-grpc/service"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+func newBoltDB(t *testing.T) *bolt.DB {
+	db, err := service.OpenBoltDB(filepath.Join(tempFolder(t), "data.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestInMemoryStores(t *testing.T) {
+	t.Parallel()
+
+	RunLaptopStoreTests(t, func(t *testing.T) service.LaptopStore {
+		return service.NewInMemoryLaptopStore()
+	})
+	RunUserStoreTests(t, func(t *testing.T) service.UserStore {
+		return service.NewInMemoryUserStore()
+	})
+	RunRatingStoreTests(t, func(t *testing.T) service.RatingStore {
+		return service.NewInMemoryRatingStore()
+	})
+	RunImageStoreTests(t, func(t *testing.T, imageFolder string) service.ImageStore {
+		return service.NewDiskImageStore(imageFolder)
+	})
+	RunRevisionStoreTests(t, func(t *testing.T) service.RevisionStore {
+		return service.NewInMemoryRevisionStore()
+	})
+	RunTrashStoreTests(t, func(t *testing.T) service.TrashStore {
+		return service.NewInMemoryTrashStore()
+	})
+}
+
+func TestDiskLaptopStore(t *testing.T) {
+	t.Parallel()
+
+	RunLaptopStoreTests(t, func(t *testing.T) service.LaptopStore {
+		store, err := service.NewDiskLaptopStore(tempFolder(t), 10)
+		require.NoError(t, err)
+		t.Cleanup(func() { store.Close() })
+		return store
+	})
+	RunRevisionStoreTests(t, func(t *testing.T) service.RevisionStore {
+		store, err := service.NewDiskRevisionStore(tempFolder(t))
+		require.NoError(t, err)
+		t.Cleanup(func() { store.Close() })
+		return store
+	})
+	RunTrashStoreTests(t, func(t *testing.T) service.TrashStore {
+		store, err := service.NewDiskTrashStore(tempFolder(t))
+		require.NoError(t, err)
+		return store
+	})
+}
+
+func TestBoltStores(t *testing.T) {
+	t.Parallel()
+
+	RunLaptopStoreTests(t, func(t *testing.T) service.LaptopStore {
+		return service.NewBoltLaptopStore(newBoltDB(t))
+	})
+	RunUserStoreTests(t, func(t *testing.T) service.UserStore {
+		return service.NewBoltUserStore(newBoltDB(t))
+	})
+	RunRatingStoreTests(t, func(t *testing.T) service.RatingStore {
+		return service.NewBoltRatingStore(newBoltDB(t))
+	})
+	RunImageStoreTests(t, func(t *testing.T, imageFolder string) service.ImageStore {
+		return service.NewBoltImageStore(newBoltDB(t), imageFolder)
+	})
+	RunRevisionStoreTests(t, func(t *testing.T) service.RevisionStore {
+		return service.NewBoltRevisionStore(newBoltDB(t))
+	})
+	RunTrashStoreTests(t, func(t *testing.T) service.TrashStore {
+		return service.NewBoltTrashStore(newBoltDB(t))
+	})
+}
+
+func TestSQLLaptopStore(t *testing.T) {
+	t.Parallel()
+
+	RunLaptopStoreTests(t, func(t *testing.T) service.LaptopStore {
+		db, err := service.OpenSQLiteDB(filepath.Join(tempFolder(t), "laptops.db"))
+		require.NoError(t, err)
+		t.Cleanup(func() { db.Close() })
+
+		return service.NewSQLLaptopStore(db)
+	})
+	RunRevisionStoreTests(t, func(t *testing.T) service.RevisionStore {
+		db, err := service.OpenSQLiteDB(filepath.Join(tempFolder(t), "laptops.db"))
+		require.NoError(t, err)
+		t.Cleanup(func() { db.Close() })
+
+		return service.NewSQLRevisionStore(db)
+	})
+	RunTrashStoreTests(t, func(t *testing.T) service.TrashStore {
+		db, err := service.OpenSQLiteDB(filepath.Join(tempFolder(t), "laptops.db"))
+		require.NoError(t, err)
+		t.Cleanup(func() { db.Close() })
+
+		return service.NewSQLTrashStore(db)
+	})
+}
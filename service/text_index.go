@@ -0,0 +1,168 @@
+package service
+
+import (
+	"demo-grpc/pb"
+	"math"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/google/btree"
+)
+
+//field weights of the text index, a match in the laptop name ranks higher than one in a GPU name
+const (
+	nameWeight  = 3.0
+	brandWeight = 2.0
+	cpuWeight   = 1.5
+	gpuWeight   = 1.0
+	//prefixMatch scales the score of a query term that is only a prefix of the indexed token
+	prefixMatch = 0.5
+)
+
+//TextIndex is an inverted index over the brand, name, CPU name and GPU names of laptops.
+//Tokens are case-folded, and query terms match tokens they are equal to or a prefix of
+type TextIndex struct {
+	mutex sync.RWMutex
+	//postings maps a token to the IDs of the laptops it appears in, with the weight of its best field
+	postings map[string]map[string]float64
+	tokens   *btree.BTree
+	docs     map[string][]string
+}
+
+type tokenItem string
+
+func (item tokenItem) Less(than btree.Item) bool {
+	return item < than.(tokenItem)
+}
+
+//NewTextIndex returns a new empty TextIndex
+func NewTextIndex() *TextIndex {
+	return &TextIndex{
+		postings: make(map[string]map[string]float64),
+		tokens:   btree.New(indexDegree),
+		docs:     make(map[string][]string),
+	}
+}
+
+//tokenize splits text into lower-case words made of letters and digits
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+//Add indexes a laptop, replacing what was indexed for the same ID before
+func (index *TextIndex) Add(laptop *pb.Laptop) {
+	weights := make(map[string]float64)
+	addField := func(text string, weight float64) {
+		for _, token := range tokenize(text) {
+			if weights[token] < weight {
+				weights[token] = weight
+			}
+		}
+	}
+
+	addField(laptop.GetName(), nameWeight)
+	addField(laptop.GetBrand(), brandWeight)
+	addField(laptop.GetCpu().GetName(), cpuWeight)
+	for _, gpu := range laptop.GetGpus() {
+		addField(gpu.GetName(), gpuWeight)
+	}
+
+	index.mutex.Lock()
+	defer index.mutex.Unlock()
+
+	index.remove(laptop.GetId())
+
+	tokens := make([]string, 0, len(weights))
+	for token, weight := range weights {
+		if index.postings[token] == nil {
+			index.postings[token] = make(map[string]float64)
+			index.tokens.ReplaceOrInsert(tokenItem(token))
+		}
+		index.postings[token][laptop.GetId()] = weight
+		tokens = append(tokens, token)
+	}
+	index.docs[laptop.GetId()] = tokens
+}
+
+//Remove removes a laptop from the index
+func (index *TextIndex) Remove(id string) {
+	index.mutex.Lock()
+	defer index.mutex.Unlock()
+
+	index.remove(id)
+}
+
+func (index *TextIndex) remove(id string) {
+	for _, token := range index.docs[id] {
+		delete(index.postings[token], id)
+		if len(index.postings[token]) == 0 {
+			delete(index.postings, token)
+			index.tokens.Delete(tokenItem(token))
+		}
+	}
+	delete(index.docs, id)
+}
+
+//Search returns the IDs of the laptops that match every term of the text, with their relevance score
+func (index *TextIndex) Search(text string) map[string]float64 {
+	index.mutex.RLock()
+	defer index.mutex.RUnlock()
+
+	var scores map[string]float64
+	for _, term := range tokenize(text) {
+		termScores := index.searchTerm(term)
+
+		if scores == nil {
+			scores = termScores
+			continue
+		}
+
+		for id, score := range scores {
+			if termScore, ok := termScores[id]; ok {
+				scores[id] = score + termScore
+			} else {
+				delete(scores, id)
+			}
+		}
+	}
+
+	if scores == nil {
+		scores = make(map[string]float64)
+	}
+	return scores
+}
+
+//searchTerm scores the laptops having a token that the term is equal to or a prefix of.
+//Rare tokens weigh more than common ones, and a laptop gets the score of its best matching token
+func (index *TextIndex) searchTerm(term string) map[string]float64 {
+	scores := make(map[string]float64)
+	total := float64(len(index.docs))
+
+	index.tokens.AscendGreaterOrEqual(tokenItem(term), func(item btree.Item) bool {
+		token := string(item.(tokenItem))
+		if !strings.HasPrefix(token, term) {
+			return false
+		}
+
+		match := 1.0
+		if token != term {
+			match = prefixMatch
+		}
+
+		postings := index.postings[token]
+		idf := math.Log(1 + total/float64(len(postings)))
+
+		for id, weight := range postings {
+			score := weight * match * idf
+			if score > scores[id] {
+				scores[id] = score
+			}
+		}
+		return true
+	})
+
+	return scores
+}
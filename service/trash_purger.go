@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"demo-grpc/pb"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+)
+
+//TrashPurger permanently removes the laptops that stayed in the trash longer than the retention period,
+//together with their ratings and images
+type TrashPurger struct {
+	retention     time.Duration
+	laptopStore   LaptopStore
+	trashStore    TrashStore
+	imageStore    ImageStore
+	ratingStore   RatingStore
+	revisionStore RevisionStore
+}
+
+//NewTrashPurger returns a new trash purger, the stores other than trashStore may be nil.
+//Without a laptopStore, a laptop created again with the ID of a trashed one loses its images and rating to the purge
+func NewTrashPurger(
+	retention time.Duration,
+	laptopStore LaptopStore,
+	trashStore TrashStore,
+	imageStore ImageStore,
+	ratingStore RatingStore,
+	revisionStore RevisionStore,
+) *TrashPurger {
+	return &TrashPurger{
+		retention:     retention,
+		laptopStore:   laptopStore,
+		trashStore:    trashStore,
+		imageStore:    imageStore,
+		ratingStore:   ratingStore,
+		revisionStore: revisionStore,
+	}
+}
+
+//Run purges the trash every interval until ctx is done
+func (purger *TrashPurger) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			purged, err := purger.Purge(now)
+			if err != nil {
+				log.Printf("Cannot purge the trash: %v", err)
+			}
+			if purged > 0 {
+				log.Printf("Purged %d laptops from the trash", purged)
+			}
+		}
+	}
+}
+
+//Purge removes the laptops deleted before now minus the retention period and returns how many it removed.
+//It stops at the first laptop it cannot remove, the next purge tries again
+func (purger *TrashPurger) Purge(now time.Time) (int, error) {
+	cutoff := now.Add(-purger.retention)
+	expired := []*pb.TrashedLaptop{}
+
+	afterID := ""
+	for {
+		laptops, err := purger.trashStore.List(afterID, maxPageSize)
+		if err != nil {
+			return 0, fmt.Errorf("Cannot list trash: %v", err)
+		}
+
+		for _, laptop := range laptops {
+			deletedAt, err := ptypes.Timestamp(laptop.GetDeletedAt())
+			if err == nil && deletedAt.Before(cutoff) {
+				expired = append(expired, laptop)
+			}
+		}
+
+		if len(laptops) < maxPageSize {
+			break
+		}
+		afterID = laptops[len(laptops)-1].GetLaptop().GetId()
+	}
+
+	for i, laptop := range expired {
+		err := purger.purge(laptop)
+		if err != nil {
+			return i, err
+		}
+	}
+	return len(expired), nil
+}
+
+func (purger *TrashPurger) purge(trashed *pb.TrashedLaptop) error {
+	laptopID := trashed.GetLaptop().GetId()
+
+	//taking the laptop out of the trash first means a concurrent restore either wins or finds nothing to restore
+	err := purger.trashStore.Remove(laptopID)
+	if err == ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Cannot remove laptop %s from the trash: %v", laptopID, err)
+	}
+
+	//the images and the rating of a laptop that is live again belong to it now, only its old trash entry goes
+	if purger.laptopStore != nil {
+		live, err := purger.laptopStore.Find(laptopID)
+		if err != nil {
+			if err := purger.trashStore.Put(trashed); err != nil {
+				log.Printf("Cannot put laptop %s back in the trash: %v", laptopID, err)
+			}
+			return fmt.Errorf("Cannot find laptop %s: %v", laptopID, err)
+		}
+		if live != nil {
+			log.Printf("Dropped laptop %s from the trash without purging it, it exists again", laptopID)
+			return nil
+		}
+	}
+
+	err = purger.cleanUp(laptopID)
+	if err != nil {
+		//the laptop goes back to the trash, so that the next purge cleans up what is left
+		if err := purger.trashStore.Put(trashed); err != nil {
+			log.Printf("Cannot put laptop %s back in the trash: %v", laptopID, err)
+		}
+		return err
+	}
+
+	if purger.revisionStore != nil {
+		revision, err := newRevision(context.Background(), pb.LaptopRevision_PURGE, trashed.GetLaptop(), 0)
+		if err != nil {
+			return err
+		}
+
+		_, err = purger.revisionStore.Append(revision)
+		if err != nil {
+			return fmt.Errorf("Cannot record revision of laptop %s: %v", laptopID, err)
+		}
+	}
+
+	return nil
+}
+
+//cleanUp deletes the images and the rating of a laptop
+func (purger *TrashPurger) cleanUp(laptopID string) error {
+	if purger.imageStore != nil {
+		images, err := purger.imageStore.List(laptopID)
+		if err != nil {
+			return fmt.Errorf("Cannot list images of laptop %s: %v", laptopID, err)
+		}
+
+		for _, image := range images {
+			err = purger.imageStore.Delete(image.ID)
+			if err != nil && err != ErrNotFound {
+				return fmt.Errorf("Cannot delete image %s: %v", image.ID, err)
+			}
+		}
+	}
+
+	if purger.ratingStore != nil {
+		err := purger.ratingStore.Delete(laptopID)
+		if err != nil {
+			return fmt.Errorf("Cannot delete rating of laptop %s: %v", laptopID, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"bytes"
+	"demo-grpc/pb"
+	"demo-grpc/sample"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrashPurger(t *testing.T) {
+	t.Parallel()
+
+	imageFolder, err := ioutil.TempDir("", "trash")
+	require.NoError(t, err)
+	defer os.RemoveAll(imageFolder)
+
+	laptopStore := NewInMemoryLaptopStore()
+	trashStore := NewInMemoryTrashStore()
+	imageStore := NewDiskImageStore(imageFolder)
+	ratingStore := NewInMemoryRatingStore()
+	revisionStore := NewInMemoryRevisionStore()
+	purger := NewTrashPurger(time.Hour, laptopStore, trashStore, imageStore, ratingStore, revisionStore)
+
+	now := time.Now()
+	trash := func(deletedAt time.Time) *pb.Laptop {
+		laptop := sample.NewLaptop()
+		timestamp, err := ptypes.TimestampProto(deletedAt)
+		require.NoError(t, err)
+		require.NoError(t, trashStore.Put(&pb.TrashedLaptop{Laptop: laptop, DeletedAt: timestamp}))
+
+		_, err = SaveImage(imageStore, laptop.Id, ".jpg", bytes.NewBuffer(sample.NewImage(".jpg", 8, 8)), 1<<20)
+		require.NoError(t, err)
+		_, err = ratingStore.Add(laptop.Id, 5)
+		require.NoError(t, err)
+		return laptop
+	}
+
+	expired := trash(now.Add(-2 * time.Hour))
+	recent := trash(now.Add(-time.Minute))
+
+	purged, err := purger.Purge(now)
+	require.NoError(t, err)
+	require.Equal(t, 1, purged)
+
+	found, err := trashStore.Find(expired.Id)
+	require.NoError(t, err)
+	require.Nil(t, found)
+
+	images, err := imageStore.List(expired.Id)
+	require.NoError(t, err)
+	require.Empty(t, images)
+
+	rating, err := ratingStore.Find(expired.Id)
+	require.NoError(t, err)
+	require.Nil(t, rating)
+
+	revision, err := revisionStore.Find(expired.Id, 1)
+	require.NoError(t, err)
+	require.Equal(t, pb.LaptopRevision_PURGE, revision.GetOperation())
+
+	//the recent laptop keeps everything until it outstays the retention
+	found, err = trashStore.Find(recent.Id)
+	require.NoError(t, err)
+	require.NotNil(t, found)
+
+	images, err = imageStore.List(recent.Id)
+	require.NoError(t, err)
+	require.Len(t, images, 1)
+
+	files, err := ioutil.ReadDir(imageFolder)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	purged, err = purger.Purge(now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, 1, purged)
+
+	//a laptop that exists again keeps its images and rating, only its trash entry is dropped
+	live := trash(now.Add(-2 * time.Hour))
+	require.NoError(t, laptopStore.Save(live))
+
+	purged, err = purger.Purge(now)
+	require.NoError(t, err)
+	require.Equal(t, 1, purged)
+
+	found, err = trashStore.Find(live.Id)
+	require.NoError(t, err)
+	require.Nil(t, found)
+
+	images, err = imageStore.List(live.Id)
+	require.NoError(t, err)
+	require.Len(t, images, 1)
+
+	rating, err = ratingStore.Find(live.Id)
+	require.NoError(t, err)
+	require.NotNil(t, rating)
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"demo-grpc/pb"
+	"sort"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+)
+
+//TrashStore is an interface to keep the soft-deleted laptops until they are restored or purged
+type TrashStore interface {
+	Put(laptop *pb.TrashedLaptop) error
+	Find(id string) (*pb.TrashedLaptop, error)
+	Remove(id string) error
+	List(afterID string, limit int) ([]*pb.TrashedLaptop, error)
+}
+
+//InMemoryTrashStore stores soft-deleted laptops in memory
+type InMemoryTrashStore struct {
+	mutex   sync.RWMutex
+	laptops map[string]*pb.TrashedLaptop
+}
+
+//NewInMemoryTrashStore returns a new InMemoryTrashStore
+func NewInMemoryTrashStore() *InMemoryTrashStore {
+	return &InMemoryTrashStore{
+		laptops: make(map[string]*pb.TrashedLaptop),
+	}
+}
+
+//Put moves a copy of the laptop to the trash, there can only be one laptop with the same ID
+func (store *InMemoryTrashStore) Put(laptop *pb.TrashedLaptop) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	id := laptop.GetLaptop().GetId()
+	if store.laptops[id] != nil {
+		return ErrAlreadyExists
+	}
+
+	store.laptops[id] = proto.Clone(laptop).(*pb.TrashedLaptop)
+	return nil
+}
+
+//Find returns a laptop of the trash by ID, or nil if it is not in the trash
+func (store *InMemoryTrashStore) Find(id string) (*pb.TrashedLaptop, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	laptop := store.laptops[id]
+	if laptop == nil {
+		return nil, nil
+	}
+
+	return proto.Clone(laptop).(*pb.TrashedLaptop), nil
+}
+
+//Remove takes a laptop out of the trash by ID
+func (store *InMemoryTrashStore) Remove(id string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if store.laptops[id] == nil {
+		return ErrNotFound
+	}
+
+	delete(store.laptops, id)
+	return nil
+}
+
+//List returns up to limit laptops of the trash in ascending ID order, starting right after afterID
+func (store *InMemoryTrashStore) List(afterID string, limit int) ([]*pb.TrashedLaptop, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	ids := make([]string, 0, len(store.laptops))
+	for id := range store.laptops {
+		if id > afterID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+
+	laptops := make([]*pb.TrashedLaptop, 0, len(ids))
+	for _, id := range ids {
+		laptops = append(laptops, proto.Clone(store.laptops[id]).(*pb.TrashedLaptop))
+	}
+	return laptops, nil
+}
@@ -0,0 +1,355 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+//ErrUploadGap is returned when a chunk starts past the end of the data already committed to an upload
+var ErrUploadGap = errors.New("Chunk starts past the committed data")
+
+//UploadStore is an interface to keep the image uploads in progress, so that an interrupted upload can be resumed
+type UploadStore interface {
+	Create(laptopID string, imageType string) (*UploadSession, error)
+	Find(uploadID string) (*UploadSession, error)
+	//Write writes a chunk at offset and returns the size of the data committed to the upload
+	Write(uploadID string, offset int64, chunk []byte) (int64, error)
+	//Finish passes the committed data of an upload to save and removes the upload once save succeeds.
+	//Writes and other finishes of the same upload wait until it returns
+	Finish(uploadID string, save func(session *UploadSession, data io.Reader) error) error
+	Remove(uploadID string) error
+	//Expire removes the uploads that got no data since before and returns how many it removed
+	Expire(before time.Time) (int, error)
+}
+
+//UploadSession describes an image upload in progress
+type UploadSession struct {
+	ID        string
+	LaptopID  string
+	ImageType string
+	CreatedAt time.Time
+	//Size is the size of the data committed to the upload so far
+	Size int64 `json:"-"`
+}
+
+//DiskUploadStore keeps each upload in a folder as two files: the session info in <id>.json
+//and the data received so far in <id>.part, which is synced after every chunk
+type DiskUploadStore struct {
+	mutex  sync.Mutex
+	folder string
+	locks  map[string]*sync.Mutex
+}
+
+//NewDiskUploadStore returns a new DiskUploadStore, creating its folder if needed.
+//Uploads left in the folder by a previous run can be resumed
+func NewDiskUploadStore(folder string) (*DiskUploadStore, error) {
+	err := os.MkdirAll(folder, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot create upload folder: %v", err)
+	}
+
+	return &DiskUploadStore{
+		folder: folder,
+		locks:  make(map[string]*sync.Mutex),
+	}, nil
+}
+
+//Create starts a new upload with no data
+func (store *DiskUploadStore) Create(laptopID string, imageType string) (*UploadSession, error) {
+	uploadID, err := uuid.NewRandom()
+	if err != nil {
+		return nil, fmt.Errorf("Cannot generate upload id: %v", err)
+	}
+
+	session := &UploadSession{
+		ID:        uploadID.String(),
+		LaptopID:  laptopID,
+		ImageType: imageType,
+		CreatedAt: time.Now(),
+	}
+
+	value, err := json.Marshal(session)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot marshal upload session: %v", err)
+	}
+
+	//the data file is created first, so that a session whose info exists always has one
+	file, err := os.Create(store.dataPath(session.ID))
+	if err != nil {
+		return nil, fmt.Errorf("Cannot create upload file: %v", err)
+	}
+	file.Close()
+
+	tmpPath := store.infoPath(session.ID) + ".tmp"
+	err = ioutil.WriteFile(tmpPath, value, 0644)
+	if err == nil {
+		err = os.Rename(tmpPath, store.infoPath(session.ID))
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		os.Remove(store.dataPath(session.ID))
+		return nil, fmt.Errorf("Cannot write upload session: %v", err)
+	}
+
+	return session, nil
+}
+
+//Find returns an upload by ID together with the size of its committed data, or nil if there is no such upload
+func (store *DiskUploadStore) Find(uploadID string) (*UploadSession, error) {
+	//only IDs made by Create can name a file of the store
+	if _, err := uuid.Parse(uploadID); err != nil {
+		return nil, nil
+	}
+
+	value, err := ioutil.ReadFile(store.infoPath(uploadID))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Cannot read upload session: %v", err)
+	}
+
+	session := &UploadSession{}
+	err = json.Unmarshal(value, session)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot unmarshal upload session: %v", err)
+	}
+
+	stat, err := os.Stat(store.dataPath(uploadID))
+	if err != nil {
+		return nil, fmt.Errorf("Cannot read upload file: %v", err)
+	}
+	session.Size = stat.Size()
+
+	return session, nil
+}
+
+//Write writes a chunk at offset, which cannot be past the committed data.
+//A chunk that starts before the end of the committed data overwrites it, so that a resumed upload may resend some data
+func (store *DiskUploadStore) Write(uploadID string, offset int64, chunk []byte) (int64, error) {
+	unlock := store.lock(uploadID)
+	defer unlock()
+
+	session, err := store.Find(uploadID)
+	if err != nil {
+		return 0, err
+	}
+	if session == nil {
+		return 0, ErrNotFound
+	}
+	if offset < 0 || offset > session.Size {
+		return session.Size, ErrUploadGap
+	}
+
+	file, err := os.OpenFile(store.dataPath(uploadID), os.O_WRONLY, 0)
+	if err != nil {
+		return session.Size, fmt.Errorf("Cannot open upload file: %v", err)
+	}
+	defer file.Close()
+
+	_, err = file.WriteAt(chunk, offset)
+	if err == nil {
+		err = file.Sync()
+	}
+	if err != nil {
+		return session.Size, fmt.Errorf("Cannot write chunk to the upload file: %v", err)
+	}
+
+	if end := offset + int64(len(chunk)); end > session.Size {
+		return end, nil
+	}
+	return session.Size, nil
+}
+
+//Finish passes the committed data of an upload to save and removes the upload once save succeeds.
+//The upload stays locked meanwhile, so that save never reads a chunk being written and an upload is saved at most once
+func (store *DiskUploadStore) Finish(uploadID string, save func(session *UploadSession, data io.Reader) error) error {
+	unlock := store.lock(uploadID)
+	defer unlock()
+
+	session, err := store.Find(uploadID)
+	if err != nil {
+		return err
+	}
+	if session == nil {
+		return ErrNotFound
+	}
+
+	file, err := os.Open(store.dataPath(uploadID))
+	if err != nil {
+		return fmt.Errorf("Cannot open upload file: %v", err)
+	}
+	defer file.Close()
+
+	err = save(session, io.LimitReader(file, session.Size))
+	if err != nil {
+		return err
+	}
+
+	//the data is saved, an upload left behind is only wasted space until it expires
+	err = store.remove(uploadID)
+	if err != nil {
+		log.Printf("Cannot remove finished upload %s: %v", uploadID, err)
+	}
+	return nil
+}
+
+//Remove discards an upload and its data
+func (store *DiskUploadStore) Remove(uploadID string) error {
+	unlock := store.lock(uploadID)
+	defer unlock()
+
+	session, err := store.Find(uploadID)
+	if err != nil {
+		return err
+	}
+	if session == nil {
+		return ErrNotFound
+	}
+	return store.remove(uploadID)
+}
+
+//Expire removes the uploads whose data was last written before, or that were created before and got no data.
+//Files left by a Create that failed halfway are removed too
+func (store *DiskUploadStore) Expire(before time.Time) (int, error) {
+	files, err := ioutil.ReadDir(store.folder)
+	if err != nil {
+		return 0, fmt.Errorf("Cannot read upload folder: %v", err)
+	}
+
+	expired := 0
+	for _, file := range files {
+		name := file.Name()
+		uploadID := strings.TrimSuffix(name, filepath.Ext(name))
+		if _, err := uuid.Parse(uploadID); err != nil {
+			//a temporary session info of Create, named <id>.json.tmp
+			if filepath.Ext(name) == ".tmp" && file.ModTime().Before(before) {
+				os.Remove(filepath.Join(store.folder, name))
+			}
+			continue
+		}
+
+		switch filepath.Ext(name) {
+		case ".json":
+			removed, err := store.expire(uploadID, before)
+			if err != nil {
+				return expired, err
+			}
+			if removed {
+				expired++
+			}
+		case ".part":
+			//data without a session info cannot be resumed
+			_, err := os.Stat(store.infoPath(uploadID))
+			if os.IsNotExist(err) && file.ModTime().Before(before) {
+				os.Remove(filepath.Join(store.folder, name))
+			}
+		}
+	}
+	return expired, nil
+}
+
+func (store *DiskUploadStore) expire(uploadID string, before time.Time) (bool, error) {
+	unlock := store.lock(uploadID)
+	defer unlock()
+
+	session, err := store.Find(uploadID)
+	if err != nil || session == nil {
+		return false, err
+	}
+
+	lastWrite := session.CreatedAt
+	stat, err := os.Stat(store.dataPath(uploadID))
+	if err == nil && stat.ModTime().After(lastWrite) {
+		lastWrite = stat.ModTime()
+	}
+	if !lastWrite.Before(before) {
+		return false, nil
+	}
+
+	err = store.remove(uploadID)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+//remove deletes the files of an upload, the caller must hold its lock
+func (store *DiskUploadStore) remove(uploadID string) error {
+	//the info goes first, so that a failure leaves no session without data
+	err := os.Remove(store.infoPath(uploadID))
+	if err != nil {
+		return fmt.Errorf("Cannot remove upload session: %v", err)
+	}
+	err = os.Remove(store.dataPath(uploadID))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("Cannot remove upload file: %v", err)
+	}
+
+	store.mutex.Lock()
+	delete(store.locks, uploadID)
+	store.mutex.Unlock()
+	return nil
+}
+
+//RunUploadExpiry removes the uploads that got no data for longer than maxAge,
+//once right away and then every interval until ctx is done
+func RunUploadExpiry(ctx context.Context, store UploadStore, maxAge time.Duration, interval time.Duration) {
+	expire := func(now time.Time) {
+		expired, err := store.Expire(now.Add(-maxAge))
+		if err != nil {
+			log.Printf("Cannot expire uploads: %v", err)
+		}
+		if expired > 0 {
+			log.Printf("Expired %d abandoned uploads", expired)
+		}
+	}
+
+	expire(time.Now())
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			expire(now)
+		}
+	}
+}
+
+//lock serializes the writes to an upload until the returned func is called
+func (store *DiskUploadStore) lock(uploadID string) func() {
+	store.mutex.Lock()
+	lock := store.locks[uploadID]
+	if lock == nil {
+		lock = &sync.Mutex{}
+		store.locks[uploadID] = lock
+	}
+	store.mutex.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
+
+func (store *DiskUploadStore) infoPath(uploadID string) string {
+	return filepath.Join(store.folder, uploadID+".json")
+}
+
+func (store *DiskUploadStore) dataPath(uploadID string) string {
+	return filepath.Join(store.folder, uploadID+".part")
+}
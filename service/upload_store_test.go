@@ -0,0 +1,192 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskUploadStore(t *testing.T) {
+	t.Parallel()
+
+	folder, err := ioutil.TempDir("", "upload")
+	require.NoError(t, err)
+	defer os.RemoveAll(folder)
+
+	store, err := NewDiskUploadStore(filepath.Join(folder, "uploads"))
+	require.NoError(t, err)
+
+	session, err := store.Create("laptop", ".png")
+	require.NoError(t, err)
+	require.NotEmpty(t, session.ID)
+
+	size, err := store.Write(session.ID, 0, []byte("hello "))
+	require.NoError(t, err)
+	require.Equal(t, int64(6), size)
+
+	size, err = store.Write(session.ID, 7, []byte("gap"))
+	require.Equal(t, ErrUploadGap, err)
+	require.Equal(t, int64(6), size)
+
+	_, err = store.Write("missing", 0, []byte("data"))
+	require.Equal(t, ErrNotFound, err)
+
+	//an upload outlives the store that started it
+	store, err = NewDiskUploadStore(filepath.Join(folder, "uploads"))
+	require.NoError(t, err)
+
+	found, err := store.Find(session.ID)
+	require.NoError(t, err)
+	require.Equal(t, "laptop", found.LaptopID)
+	require.Equal(t, ".png", found.ImageType)
+	require.Equal(t, int64(6), found.Size)
+
+	size, err = store.Write(session.ID, 6, []byte("world"))
+	require.NoError(t, err)
+	require.Equal(t, int64(11), size)
+
+	//a resent chunk overwrites the data without growing it
+	size, err = store.Write(session.ID, 0, []byte("HELLO"))
+	require.NoError(t, err)
+	require.Equal(t, int64(11), size)
+
+	for _, id := range []string{"missing", "../" + session.ID} {
+		found, err = store.Find(id)
+		require.NoError(t, err)
+		require.Nil(t, found)
+	}
+
+	//a failed save keeps the upload
+	saveErr := errors.New("cannot save")
+	err = store.Finish(session.ID, func(*UploadSession, io.Reader) error { return saveErr })
+	require.Equal(t, saveErr, err)
+
+	err = store.Finish(session.ID, func(found *UploadSession, data io.Reader) error {
+		require.Equal(t, int64(11), found.Size)
+		value, err := ioutil.ReadAll(data)
+		require.NoError(t, err)
+		require.Equal(t, "HELLO world", string(value))
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, ErrNotFound, store.Finish(session.ID, func(*UploadSession, io.Reader) error { return nil }))
+	require.Equal(t, ErrNotFound, store.Remove(session.ID))
+
+	session, err = store.Create("laptop", ".png")
+	require.NoError(t, err)
+	require.NoError(t, store.Remove(session.ID))
+	require.Equal(t, ErrNotFound, store.Remove(session.ID))
+
+	files, err := ioutil.ReadDir(filepath.Join(folder, "uploads"))
+	require.NoError(t, err)
+	require.Empty(t, files)
+}
+
+func TestDiskUploadStoreFinishLocksUpload(t *testing.T) {
+	t.Parallel()
+
+	folder, err := ioutil.TempDir("", "upload")
+	require.NoError(t, err)
+	defer os.RemoveAll(folder)
+
+	store, err := NewDiskUploadStore(folder)
+	require.NoError(t, err)
+
+	session, err := store.Create("laptop", ".png")
+	require.NoError(t, err)
+	_, err = store.Write(session.ID, 0, []byte("hello"))
+	require.NoError(t, err)
+
+	saving := make(chan struct{})
+	saved := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- store.Finish(session.ID, func(*UploadSession, io.Reader) error {
+			close(saving)
+			<-saved
+			return nil
+		})
+	}()
+	<-saving
+
+	//a chunk and a second finish wait for the first finish, then find the upload gone
+	written := make(chan error)
+	go func() {
+		_, err := store.Write(session.ID, 5, []byte(" world"))
+		written <- err
+	}()
+	finished := make(chan error)
+	go func() {
+		finished <- store.Finish(session.ID, func(*UploadSession, io.Reader) error {
+			return errors.New("saved twice")
+		})
+	}()
+
+	select {
+	case err := <-written:
+		t.Fatalf("chunk written during finish: %v", err)
+	case err := <-finished:
+		t.Fatalf("upload finished twice: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(saved)
+	require.NoError(t, <-done)
+	require.Equal(t, ErrNotFound, <-written)
+	require.Equal(t, ErrNotFound, <-finished)
+}
+
+func TestDiskUploadStoreExpire(t *testing.T) {
+	t.Parallel()
+
+	folder, err := ioutil.TempDir("", "upload")
+	require.NoError(t, err)
+	defer os.RemoveAll(folder)
+
+	store, err := NewDiskUploadStore(folder)
+	require.NoError(t, err)
+
+	old, err := store.Create("laptop", ".png")
+	require.NoError(t, err)
+	resumed, err := store.Create("laptop", ".png")
+	require.NoError(t, err)
+
+	//an upload counts as active from its last chunk, not from its creation
+	past := time.Now().Add(-2 * time.Hour)
+	for _, session := range []*UploadSession{old, resumed} {
+		session.CreatedAt = past
+		value, err := json.Marshal(session)
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(store.infoPath(session.ID), value, 0644))
+		require.NoError(t, os.Chtimes(store.dataPath(session.ID), past, past))
+	}
+	_, err = store.Write(resumed.ID, 0, []byte("data"))
+	require.NoError(t, err)
+
+	//the data of a Create that failed before writing the session info
+	orphan := filepath.Join(folder, "a55e7e1c-0c4f-4f0a-9d6e-3b1b8d4e2f10.part")
+	require.NoError(t, ioutil.WriteFile(orphan, []byte("data"), 0644))
+	require.NoError(t, os.Chtimes(orphan, past, past))
+
+	expired, err := store.Expire(time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, 1, expired)
+
+	found, err := store.Find(old.ID)
+	require.NoError(t, err)
+	require.Nil(t, found)
+	found, err = store.Find(resumed.ID)
+	require.NoError(t, err)
+	require.NotNil(t, found)
+
+	files, err := ioutil.ReadDir(folder)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+}
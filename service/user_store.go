@@ -1,11 +1,16 @@
 package service
 
-import "sync"
+import (
+	"sort"
+	"sync"
+)
 
 //UserStore is an interface to store users
 type UserStore interface {
 	Save(user *User) error
 	Find(username string) (*User, error)
+	List() ([]*User, error)
+	Delete(username string) error
 }
 
 //InMemoryUserStore stores users in memory
@@ -46,3 +51,32 @@ func (store *InMemoryUserStore) Find(username string) (*User, error) {
 
 	return user.Clone(), nil
 }
+
+//List returns every user, ordered by username
+func (store *InMemoryUserStore) List() ([]*User, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	users := make([]*User, 0, len(store.users))
+	for _, user := range store.users {
+		users = append(users, user.Clone())
+	}
+
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].Username < users[j].Username
+	})
+	return users, nil
+}
+
+//Delete removes a user by username
+func (store *InMemoryUserStore) Delete(username string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if store.users[username] == nil {
+		return ErrNotFound
+	}
+
+	delete(store.users, username)
+	return nil
+}
@@ -0,0 +1,258 @@
+package service
+
+import (
+	"demo-grpc/pb"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+//ErrRestoring is returned by the writes that arrive while the server state is being restored
+var ErrRestoring = errors.New("Server state is being restored")
+
+//WriteGate lets the admin server hold back every write to the stores it guards.
+//Writes run concurrently with each other, a snapshot waits for the writes in flight and blocks new ones,
+//and a restore refuses new writes with ErrRestoring until it is done
+type WriteGate struct {
+	mutex     sync.RWMutex
+	restoring int32
+	restores  uint64
+}
+
+//NewWriteGate returns a new WriteGate
+func NewWriteGate() *WriteGate {
+	return &WriteGate{}
+}
+
+//enter is called before a write, the write must call the returned func once it is done
+func (gate *WriteGate) enter() (func(), error) {
+	restores := atomic.LoadUint64(&gate.restores)
+	if atomic.LoadInt32(&gate.restoring) != 0 {
+		return nil, ErrRestoring
+	}
+
+	gate.mutex.RLock()
+
+	//a restore that started while the write was waiting for the lock has replaced the state the write was based on
+	if atomic.LoadUint64(&gate.restores) != restores {
+		gate.mutex.RUnlock()
+		return nil, ErrRestoring
+	}
+	return gate.mutex.RUnlock, nil
+}
+
+//freeze blocks writes until the returned func is called
+func (gate *WriteGate) freeze() func() {
+	gate.mutex.Lock()
+	return gate.mutex.Unlock
+}
+
+//beginRestore refuses writes until the returned func is called, only one restore can run at a time
+func (gate *WriteGate) beginRestore() (func(), error) {
+	if !atomic.CompareAndSwapInt32(&gate.restoring, 0, 1) {
+		return nil, ErrRestoring
+	}
+
+	atomic.AddUint64(&gate.restores, 1)
+	gate.mutex.Lock()
+
+	return func() {
+		atomic.StoreInt32(&gate.restoring, 0)
+		gate.mutex.Unlock()
+	}, nil
+}
+
+//guard runs a write through the gate
+func (gate *WriteGate) guard(write func() error) error {
+	leave, err := gate.enter()
+	if err != nil {
+		return err
+	}
+	defer leave()
+
+	return write()
+}
+
+//LaptopStore returns a LaptopStore whose writes go through the gate.
+//The returned store is also a LaptopWatcher or a LaptopBatchSaver if the given one is
+func (gate *WriteGate) LaptopStore(store LaptopStore) LaptopStore {
+	guarded := &guardedLaptopStore{LaptopStore: store, gate: gate}
+	watcher, canWatch := store.(LaptopWatcher)
+	saver, canSaveAll := store.(LaptopBatchSaver)
+	batch := &guardedBatchSaver{saver: saver, gate: gate}
+
+	switch {
+	case canWatch && canSaveAll:
+		return &struct {
+			*guardedLaptopStore
+			LaptopWatcher
+			*guardedBatchSaver
+		}{guarded, watcher, batch}
+	case canWatch:
+		return &struct {
+			*guardedLaptopStore
+			LaptopWatcher
+		}{guarded, watcher}
+	case canSaveAll:
+		return &struct {
+			*guardedLaptopStore
+			*guardedBatchSaver
+		}{guarded, batch}
+	default:
+		return guarded
+	}
+}
+
+//UserStore returns a UserStore whose writes go through the gate
+func (gate *WriteGate) UserStore(store UserStore) UserStore {
+	return &guardedUserStore{UserStore: store, gate: gate}
+}
+
+//RatingStore returns a RatingStore whose writes go through the gate
+func (gate *WriteGate) RatingStore(store RatingStore) RatingStore {
+	return &guardedRatingStore{RatingStore: store, gate: gate}
+}
+
+//ImageStore returns an ImageStore whose writes go through the gate
+func (gate *WriteGate) ImageStore(store ImageStore) ImageStore {
+	return &guardedImageStore{ImageStore: store, gate: gate}
+}
+
+//TrashStore returns a TrashStore whose writes go through the gate
+func (gate *WriteGate) TrashStore(store TrashStore) TrashStore {
+	return &guardedTrashStore{TrashStore: store, gate: gate}
+}
+
+type guardedLaptopStore struct {
+	LaptopStore
+	gate *WriteGate
+}
+
+func (store *guardedLaptopStore) Save(laptop *pb.Laptop) error {
+	return store.gate.guard(func() error {
+		return store.LaptopStore.Save(laptop)
+	})
+}
+
+func (store *guardedLaptopStore) Update(laptop *pb.Laptop, expectedVersion uint64) (uint64, error) {
+	var version uint64
+	err := store.gate.guard(func() error {
+		var err error
+		version, err = store.LaptopStore.Update(laptop, expectedVersion)
+		return err
+	})
+	return version, err
+}
+
+func (store *guardedLaptopStore) Delete(id string, expectedVersion uint64) error {
+	return store.gate.guard(func() error {
+		return store.LaptopStore.Delete(id, expectedVersion)
+	})
+}
+
+type guardedBatchSaver struct {
+	saver LaptopBatchSaver
+	gate  *WriteGate
+}
+
+func (store *guardedBatchSaver) SaveAll(laptops []*pb.Laptop) error {
+	return store.gate.guard(func() error {
+		return store.saver.SaveAll(laptops)
+	})
+}
+
+type guardedUserStore struct {
+	UserStore
+	gate *WriteGate
+}
+
+func (store *guardedUserStore) Save(user *User) error {
+	return store.gate.guard(func() error {
+		return store.UserStore.Save(user)
+	})
+}
+
+func (store *guardedUserStore) Delete(username string) error {
+	return store.gate.guard(func() error {
+		return store.UserStore.Delete(username)
+	})
+}
+
+type guardedRatingStore struct {
+	RatingStore
+	gate *WriteGate
+}
+
+func (store *guardedRatingStore) Add(laptopID string, score float64) (*Rating, error) {
+	var rating *Rating
+	err := store.gate.guard(func() error {
+		var err error
+		rating, err = store.RatingStore.Add(laptopID, score)
+		return err
+	})
+	return rating, err
+}
+
+func (store *guardedRatingStore) Set(laptopID string, rating *Rating) error {
+	return store.gate.guard(func() error {
+		return store.RatingStore.Set(laptopID, rating)
+	})
+}
+
+func (store *guardedRatingStore) Delete(laptopID string) error {
+	return store.gate.guard(func() error {
+		return store.RatingStore.Delete(laptopID)
+	})
+}
+
+type guardedImageStore struct {
+	ImageStore
+	gate *WriteGate
+}
+
+//Save only holds the gate while the staged image is moved into place, staging is not a write to the store
+//and can take as long as a client takes to send the image
+func (store *guardedImageStore) Save(laptopID string, staged *StagedImage) (string, error) {
+	var imageID string
+	err := store.gate.guard(func() error {
+		var err error
+		imageID, err = store.ImageStore.Save(laptopID, staged)
+		return err
+	})
+	return imageID, err
+}
+
+func (store *guardedImageStore) SaveInfo(info *ImageInfo) error {
+	return store.gate.guard(func() error {
+		return store.ImageStore.SaveInfo(info)
+	})
+}
+
+func (store *guardedImageStore) SaveVariant(imageID string, maxSide int, staged *StagedImage) error {
+	return store.gate.guard(func() error {
+		return store.ImageStore.SaveVariant(imageID, maxSide, staged)
+	})
+}
+
+func (store *guardedImageStore) Delete(imageID string) error {
+	return store.gate.guard(func() error {
+		return store.ImageStore.Delete(imageID)
+	})
+}
+
+type guardedTrashStore struct {
+	TrashStore
+	gate *WriteGate
+}
+
+func (store *guardedTrashStore) Put(laptop *pb.TrashedLaptop) error {
+	return store.gate.guard(func() error {
+		return store.TrashStore.Put(laptop)
+	})
+}
+
+func (store *guardedTrashStore) Remove(id string) error {
+	return store.gate.guard(func() error {
+		return store.TrashStore.Remove(id)
+	})
+}